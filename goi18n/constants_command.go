@@ -43,7 +43,7 @@ package {{.PackageName}}
 {{range .Constants}}
 // {{.Name}} is the identifier for the following localizable string template(s):{{range .Comments}}
 // {{.}}{{end}}
-const {{.Name}} = "{{.ID}}"
+const {{.Name}} = {{printf "%q" .ID}}
 {{end}}`))
 
 func (cc *constantsCommand) execute() error {
@@ -60,12 +60,12 @@ func (cc *constantsCommand) execute() error {
 	translations := bundle.Translations()
 	lang := translations[bundle.LanguageTags()[0]]
 
-	// create an array of id to organize
+	// create an array of keys to organize
 	keys := make([]string, len(lang))
 	i := 0
 
-	for id := range lang {
-		keys[i] = id
+	for key := range lang {
+		keys[i] = key
 		i++
 	}
 	sort.Strings(keys)
@@ -75,10 +75,15 @@ func (cc *constantsCommand) execute() error {
 		Constants:   make([]templateConstants, len(keys)),
 	}
 
-	for i, id := range keys {
-		tmpl.Constants[i].ID = id
-		tmpl.Constants[i].Name = toCamelCase(id)
-		tmpl.Constants[i].Comments = toComments(lang[id])
+	for i, key := range keys {
+		// Translations with the same id and different contexts get different constants.
+		name := key
+		if context, id := translation.SplitKey(key); context != "" {
+			name = context + "_" + id
+		}
+		tmpl.Constants[i].ID = key
+		tmpl.Constants[i].Name = toCamelCase(name)
+		tmpl.Constants[i].Comments = toComments(lang[key])
 	}
 
 	filename := filepath.Join(cc.outdir, cc.packageName+".go")
@@ -212,6 +217,29 @@ func toComments(trans translation.Translation) []string {
 
 	t := data["translation"]
 
+	if variants, ok := t.(map[string]interface{}); ok && data["select"] != nil {
+		// Select translations are commented one variant at a time.
+		values := make([]string, 0, len(variants))
+		for value := range variants {
+			values = append(values, value)
+		}
+		sort.Strings(values)
+		for _, value := range values {
+			separator := "."
+			if reflect.ValueOf(variants[value]).Kind() != reflect.Map {
+				separator = ": "
+			}
+			for _, comment := range pluralComments(variants[value]) {
+				result = append(result, value+separator+comment)
+			}
+		}
+		return result
+	}
+	return pluralComments(t)
+}
+
+func pluralComments(t interface{}) []string {
+	var result []string
 	switch v := reflect.ValueOf(t); v.Kind() {
 	case reflect.Map:
 		for _, k := range []language.Plural{"zero", "one", "two", "few", "many", "other"} {
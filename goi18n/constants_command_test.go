@@ -1,6 +1,11 @@
 package main
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/i18n/translation"
+)
 
 func TestConstantsExecute(t *testing.T) {
 	resetDir(t, "testdata/output")
@@ -18,6 +23,40 @@ func TestConstantsExecute(t *testing.T) {
 	expectEqualFiles(t, "testdata/output/R.go", "testdata/expected/R.go")
 }
 
+func TestConstantsExecuteContext(t *testing.T) {
+	resetDir(t, "testdata/output")
+
+	cc := &constantsCommand{
+		translationFiles: []string{"testdata/input/context/en-us.json"},
+		packageName:      "R",
+		outdir:           "testdata/output",
+	}
+
+	if err := cc.execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	expectEqualFiles(t, "testdata/output/R.go", "testdata/expected/context/R.go")
+}
+
+func TestToCommentsSelect(t *testing.T) {
+	trans, err := translation.NewTranslation(map[string]interface{}{
+		"id":     "person_photo_count",
+		"select": "Gender",
+		"translation": map[string]interface{}{
+			"female": map[string]interface{}{"one": "her photo", "other": "her photos"},
+			"other":  "their photos",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{`female.one: "her photo"`, `female.other: "her photos"`, `other: "their photos"`}
+	if actual := toComments(trans); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("toComments() = %q; expected %q", actual, expected)
+	}
+}
+
 func TestToCamelCase(t *testing.T) {
 	expectEqual := func(test, expected string) {
 		result := toCamelCase(test)
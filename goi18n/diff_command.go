@@ -0,0 +1,290 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/nicksnyder/go-i18n/i18n/bundle"
+	"github.com/nicksnyder/go-i18n/i18n/language"
+	"github.com/nicksnyder/go-i18n/i18n/translation"
+)
+
+type diffCommand struct {
+	oldPath string
+	newPath string
+	format  string
+	out     io.Writer
+}
+
+// languageDiff holds the changes to the translations of one language.
+type languageDiff struct {
+	Added   []translationDiff `json:"added,omitempty"`
+	Removed []translationDiff `json:"removed,omitempty"`
+	Changed []translationDiff `json:"changed,omitempty"`
+}
+
+type translationDiff struct {
+	ID      string `json:"id"`
+	Context string `json:"context,omitempty"`
+
+	// Categories holds the plural categories of a changed plural translation that changed.
+	// The categories of a variant of a select translation are prefixed by the value
+	// of the variant (e.g. "female.one"), and a variant that is not plural is its value.
+	Categories []string `json:"categories,omitempty"`
+}
+
+func (dc *diffCommand) execute() error {
+	if dc.oldPath == "" || dc.newPath == "" {
+		return fmt.Errorf("need an old and a new path to diff")
+	}
+	if dc.format != "text" && dc.format != "json" {
+		return fmt.Errorf("unsupported format %s", dc.format)
+	}
+
+	oldTranslations, err := loadTranslations(dc.oldPath)
+	if err != nil {
+		return err
+	}
+	newTranslations, err := loadTranslations(dc.newPath)
+	if err != nil {
+		return err
+	}
+	diffs := diffTranslations(oldTranslations, newTranslations)
+
+	out := dc.out
+	if out == nil {
+		out = os.Stdout
+	}
+	if dc.format == "json" {
+		buf, err := json.MarshalIndent(diffs, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(out, "%s\n", buf)
+		return err
+	}
+	return writeTextDiff(out, diffs)
+}
+
+// loadTranslations loads the translation file at path, or every file in the directory at path.
+func loadTranslations(path string) (map[string]map[string]translation.Translation, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	filenames := []string{path}
+	if fi.IsDir() {
+		files, err := ioutil.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		filenames = filenames[:0]
+		for _, f := range files {
+			if !f.IsDir() {
+				filenames = append(filenames, filepath.Join(path, f.Name()))
+			}
+		}
+	}
+	bundle := bundle.New()
+	for _, filename := range filenames {
+		if err := bundle.LoadTranslationFile(filename); err != nil {
+			return nil, fmt.Errorf("failed to load translation file %s: %s", filename, err)
+		}
+	}
+	return bundle.Translations(), nil
+}
+
+// diffTranslations returns the changes from oldTranslations to newTranslations by language tag.
+// Languages without changes are omitted.
+func diffTranslations(oldTranslations, newTranslations map[string]map[string]translation.Translation) map[string]*languageDiff {
+	localeIDs := make(map[string]struct{}, len(newTranslations))
+	for localeID := range oldTranslations {
+		localeIDs[localeID] = struct{}{}
+	}
+	for localeID := range newTranslations {
+		localeIDs[localeID] = struct{}{}
+	}
+	diffs := make(map[string]*languageDiff)
+	for localeID := range localeIDs {
+		var added, removed, changed []translation.Translation
+		oldLocale, newLocale := oldTranslations[localeID], newTranslations[localeID]
+		for key := range translationKeys(oldLocale, newLocale) {
+			o, n := oldLocale[key], newLocale[key]
+			switch {
+			case o == nil:
+				added = append(added, n)
+			case n == nil:
+				removed = append(removed, o)
+			case !sameTranslation(o, n):
+				changed = append(changed, n)
+			}
+		}
+		if len(added) == 0 && len(removed) == 0 && len(changed) == 0 {
+			continue
+		}
+		diffs[localeID] = &languageDiff{
+			Added:   toTranslationDiffs(added, nil),
+			Removed: toTranslationDiffs(removed, nil),
+			Changed: toTranslationDiffs(changed, func(t translation.Translation) []string {
+				key := translation.KeyOf(t)
+				return changedCategories(oldLocale[key], newLocale[key])
+			}),
+		}
+	}
+	return diffs
+}
+
+// translationKeys returns the keys of a and b.
+func translationKeys(a, b map[string]translation.Translation) map[string]struct{} {
+	keys := make(map[string]struct{}, len(a)+len(b))
+	for k := range a {
+		keys[k] = struct{}{}
+	}
+	for k := range b {
+		keys[k] = struct{}{}
+	}
+	return keys
+}
+
+func toTranslationDiffs(translations []translation.Translation, categories func(translation.Translation) []string) []translationDiff {
+	sort.Sort(translation.SortableByID(translations))
+	var diffs []translationDiff
+	for _, t := range translations {
+		diff := translationDiff{ID: t.ID(), Context: t.Metadata().Context}
+		if categories != nil {
+			diff.Categories = categories(t)
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs
+}
+
+// sameTranslation reports whether a and b marshal to the same data.
+func sameTranslation(a, b translation.Translation) bool {
+	bufA, errA := json.Marshal(a.MarshalInterface())
+	bufB, errB := json.Marshal(b.MarshalInterface())
+	return errA == nil && errB == nil && string(bufA) == string(bufB)
+}
+
+// changedCategories returns the plural categories whose templates differ between the plural translations o and n,
+// or the changed categories of the variants of the select translations o and n.
+// It returns nil if o and n are not both plural or both select translations.
+func changedCategories(o, n translation.Translation) []string {
+	if oldSelect, ok := o.(translation.Selector); ok {
+		newSelect, ok := n.(translation.Selector)
+		if !ok || oldSelect.SelectKey() != newSelect.SelectKey() {
+			return nil
+		}
+		oldVariants, newVariants := oldSelect.Variants(), newSelect.Variants()
+		var categories []string
+		for value := range translationKeys(oldVariants, newVariants) {
+			ov, nv := oldVariants[value], newVariants[value]
+			if ov != nil && nv != nil && sameTranslation(ov, nv) {
+				continue
+			}
+			variantCategories := changedCategories(ov, nv)
+			if variantCategories == nil {
+				categories = append(categories, value)
+			}
+			for _, pc := range variantCategories {
+				categories = append(categories, value+"."+pc)
+			}
+		}
+		sort.Strings(categories)
+		return categories
+	}
+
+	oldPlurals, newPlurals := translation.Plurals(o), translation.Plurals(n)
+	if oldPlurals == nil || newPlurals == nil {
+		return nil
+	}
+	var categories []string
+	for _, pc := range []language.Plural{language.Zero, language.One, language.Two, language.Few, language.Many, language.Other} {
+		ot, nt := o.Template(pc), n.Template(pc)
+		if (ot == nil) != (nt == nil) || ot != nil && ot.String() != nt.String() {
+			categories = append(categories, string(pc))
+		}
+	}
+	return categories
+}
+
+func writeTextDiff(w io.Writer, diffs map[string]*languageDiff) error {
+	localeIDs := make([]string, 0, len(diffs))
+	for localeID := range diffs {
+		localeIDs = append(localeIDs, localeID)
+	}
+	sort.Strings(localeIDs)
+	for _, localeID := range localeIDs {
+		if _, err := fmt.Fprintf(w, "%s\n", localeID); err != nil {
+			return err
+		}
+		diff := diffs[localeID]
+		for _, changes := range []struct {
+			prefix string
+			diffs  []translationDiff
+		}{{"+", diff.Added}, {"-", diff.Removed}, {"~", diff.Changed}} {
+			for _, d := range changes.diffs {
+				line := "  " + changes.prefix + " " + d.ID
+				if d.Context != "" {
+					line += fmt.Sprintf(" (context %q)", d.Context)
+				}
+				if len(d.Categories) > 0 {
+					line += fmt.Sprintf(" %v", d.Categories)
+				}
+				if _, err := fmt.Fprintln(w, line); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (dc *diffCommand) parse(arguments []string) {
+	flags := flag.NewFlagSet("diff", flag.ExitOnError)
+	flags.Usage = usageDiff
+
+	format := flags.String("format", "text", "")
+
+	flags.Parse(arguments)
+
+	if args := flags.Args(); len(args) == 2 {
+		dc.oldPath, dc.newPath = args[0], args[1]
+	}
+	dc.format = *format
+}
+
+func usageDiff() {
+	fmt.Printf(`Show the changes between two versions of translation files.
+
+Usage:
+
+    goi18n diff [options] old new
+
+Paths:
+
+    old and new are translation files or directories. Every file in a directory is loaded
+    as a translation file, so a directory must only contain translation files.
+
+Output:
+
+    For each language that changed, goi18n prints the added (+), removed (-) and changed (~)
+    translation ids. A changed plural translation is followed by the plural categories that
+    changed (e.g. [one other]), and a changed select translation by the variants that changed
+    (e.g. [female male.one]).
+
+Options:
+
+    -format format
+        goi18n writes the changes in this format.
+        Supported formats: text, json
+        Default: text
+
+`)
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+)
+
+func TestDiffExecute(t *testing.T) {
+	for _, format := range []string{"text", "json"} {
+		var out bytes.Buffer
+		dc := &diffCommand{
+			oldPath: "testdata/input/diff/old",
+			newPath: "testdata/input/diff/new",
+			format:  format,
+			out:     &out,
+		}
+		if err := dc.execute(); err != nil {
+			t.Fatal(err)
+		}
+
+		expectedName := "testdata/expected/diff/diff.txt"
+		if format == "json" {
+			expectedName = "testdata/expected/diff/diff.json"
+		}
+		expected, err := ioutil.ReadFile(expectedName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(out.Bytes(), expected) {
+			t.Errorf("%s diff =\n%s\nexpected\n%s", format, out.Bytes(), expected)
+		}
+	}
+}
+
+func TestDiffExecuteSameFiles(t *testing.T) {
+	var out bytes.Buffer
+	dc := &diffCommand{
+		oldPath: "testdata/input/diff/new/fr-fr.json",
+		newPath: "testdata/input/diff/new/fr-fr.json",
+		format:  "text",
+		out:     &out,
+	}
+	if err := dc.execute(); err != nil {
+		t.Fatal(err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("expected no changes but got\n%s", out.Bytes())
+	}
+}
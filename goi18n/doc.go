@@ -11,6 +11,11 @@
 //
 //         goi18n merge     Merge translation files
 //         goi18n constants Generate constant file from translation file
+//         goi18n extract   Extract translation ids from Go source files
+//         goi18n lint      Check translation files for broken and suspicious translations
+//         goi18n diff      Show the changes between two versions of translation files
+//         goi18n stats     Report how complete the translations of each language are
+//         goi18n pseudo    Generate pseudo-localized translation files
 //
 //     For more details execute:
 //
@@ -40,6 +45,13 @@
 //                 The translations for the strings in this file will be extracted from the source language.
 //                 After they are translated, merge them back into xx-yy.all.format using goi18n.
 //
+//         When the format is po, xliff12 or xliff20, the untranslated strings are left empty and the
+//         source language strings are written next to them instead.
+//         When the format is po, goi18n also produces a gettext template:
+//
+//             xx-yy.pot
+//                 This file contains all strings of the source language xx-yy with empty translations.
+//
 //     Merging:
 //
 //         goi18n will merge multiple translation files for the same language.
@@ -47,6 +59,9 @@
 //         Non-empty fields in the duplicate translation will overwrite those fields in the existing translation.
 //         Empty fields in the duplicate translation are ignored.
 //
+//         The description, context, maxLength and html flag of a source language translation are copied to
+//         the translations of the other languages unless those translations have their own.
+//
 //     Adding a new language:
 //
 //         To produce translation files for a new language, create an empty translation file with the
@@ -64,9 +79,16 @@
 //
 //         -format format
 //             goi18n encodes the output translation files in this format.
-//             Supported formats: json, yaml
+//             Supported formats: json, toml, yaml, po, xliff12 (XLIFF 1.2), xliff20 (XLIFF 2.0)
+//             XLIFF files of both versions are written with the .xlf extension.
 //             Default: json
 //
+//         -flat
+//             goi18n writes the output translation files in flat format.
+//             Usage of '-format toml' automitically sets this flag.
+//             It is ignored for '-format po', '-format xliff12' and '-format xliff20'.
+//             Default: true
+//
 //     Generate constant file from translation file.
 //
 //     Usage:
@@ -90,4 +112,203 @@
 //             goi18n writes the constant file to this directory.
 //             Default: .
 //
+//     Extract translation ids from Go source files.
+//
+//     Usage:
+//
+//         goi18n extract [options] [paths...]
+//
+//     Paths:
+//
+//         Each path is a Go file or a directory (e.g. ./...), which is searched recursively for
+//         Go files. Directories named testdata and vendor are skipped.
+//
+//     Extraction:
+//
+//         goi18n finds calls of translate functions: variables assigned the result of Tfunc,
+//         MustTfunc, TfuncAndLanguage, MustTfuncAndLanguage and IdentityTfunc (ContextTfunc and
+//         MustContextTfunc for context translate functions), variables, parameters and fields
+//         of type TranslateFunc or ContextTranslateFunc, and i18n.T (e.g. i18n.T(ctx, "program_greeting")).
+//
+//         The translation id of a call must be a string literal, a string constant (such as the
+//         constants generated by goi18n constants) or a concatenation of them. goi18n reports the
+//         calls whose translation id can not be resolved.
+//
+//         A translation is plural if it is called with a count, a language.Range,
+//         template data with a Count field, or a plural default message. A count is a
+//         number, a len call, or a variable or field named n, count or *Count (e.g. unreadCount).
+//
+//         A translation.Default literal after the translation id is the default message
+//         of the translation (e.g. T("page_title", translation.Default{Other: "Home"})).
+//
+//     Output:
+//
+//         goi18n writes the translations to xx-yy.all.format for the source language xx-yy.
+//         If that file exists, its translations are kept and the new translation ids are added
+//         to it. New and untranslated translations get their default messages, if any.
+//
+//     Options:
+//
+//         -sourceLanguage tag
+//             goi18n writes the translations of this language.
+//             Default: en-us
+//
+//         -outdir directory
+//             goi18n reads and writes the translation file in this directory.
+//             Default: .
+//
+//         -format format
+//             goi18n encodes the translation file in this format.
+//             Supported formats: json, toml, yaml
+//             Default: json
+//
+//         -flat
+//             goi18n writes the translation file in flat format.
+//             Usage of '-format toml' automitically sets this flag.
+//             Default: true
+//
+//     Check translation files for broken and suspicious translations.
+//
+//     Usage:
+//
+//         goi18n lint [options] [files...]
+//
+//     Translation files:
+//
+//         A translation file contains the strings and translations for a single language.
+//
+//         Translation file names must have a suffix of a supported format (e.g. .json) and
+//         contain a valid language tag as defined by RFC 5646 (e.g. en-us, fr, zh-hant, etc.).
+//
+//     Problems:
+//
+//         goi18n reports:
+//           - translation files that fail to load.
+//           - translations whose template fails to parse. The other translations of the file
+//             are still checked.
+//           - template variables (e.g. {{.Person}}) of a source translation that a translation is
+//             missing, and variables of a translation that the source translation does not have.
+//             Untranslated translations are not checked.
+//           - plural categories of a translation that its language does not have.
+//           - plural categories of its language that a plural translation is missing.
+//
+//         goi18n exits with status 1 if it finds a problem, so it can be used in continuous integration.
+//
+//     Options:
+//
+//         -sourceLanguage tag
+//             Translations are compared with the translations of this language.
+//             Default: en-us
+//
+//     Show the changes between two versions of translation files.
+//
+//     Usage:
+//
+//         goi18n diff [options] old new
+//
+//     Paths:
+//
+//         old and new are translation files or directories. Every file in a directory is loaded
+//         as a translation file, so a directory must only contain translation files.
+//
+//     Output:
+//
+//         For each language that changed, goi18n prints the added (+), removed (-) and changed (~)
+//         translation ids. A changed plural translation is followed by the plural categories that
+//         changed (e.g. [one other]), and a changed select translation by the variants that changed
+//         (e.g. [female male.one]).
+//
+//     Options:
+//
+//         -format format
+//             goi18n writes the changes in this format.
+//             Supported formats: text, json
+//             Default: text
+//
+//     Report how complete the translations of each language are.
+//
+//     Usage:
+//
+//         goi18n stats [options] [files...]
+//
+//     Translation files:
+//
+//         A translation file contains the strings and translations for a single language.
+//
+//         Translation file names must have a suffix of a supported format (e.g. .json) and
+//         contain a valid language tag as defined by RFC 5646 (e.g. en-us, fr, zh-hant, etc.).
+//
+//     Output:
+//
+//         goi18n prints a table with a row for each language:
+//
+//         TOTAL       the number of translation ids of the source language.
+//         TRANSLATED  the number of those ids that are fully translated.
+//         PARTIAL     the number of plural translations that are missing some plural categories.
+//         MISSING     the number of those ids that are not translated.
+//         COMPLETE    the percentage of those ids that are fully translated.
+//
+//         A translation is fully translated if goi18n merge would not write it to the
+//         untranslated file of the language.
+//
+//     Options:
+//
+//         -sourceLanguage tag
+//             Translations are counted against the translation ids of this language.
+//             Default: en-us
+//
+//         -min percent
+//             goi18n exits with status 1 if a language is less than percent complete.
+//             Default: 0
+//
+//     Generate pseudo-localized translation files from the translations of the source language.
+//
+//     Usage:
+//
+//         goi18n pseudo [options] [files...]
+//
+//     Translation files:
+//
+//         A translation file contains the strings and translations for a single language.
+//
+//         Translation file names must have a suffix of a supported format (e.g. .json) and
+//         contain a valid language tag as defined by RFC 5646 (e.g. en-us, fr, zh-hant, etc.).
+//
+//     Pseudo-locales:
+//
+//         en-XA   Letters are accented and messages are about 40% longer, between brackets
+//                 (e.g. "[Ĥéļļö {{.Person}} one two]"), which shows hard-coded strings and truncation.
+//         ar-XB   Words display right-to-left, which shows the layout of right-to-left languages.
+//
+//         Template actions (e.g. {{.Count}}) and ICU MessageFormat arguments are not changed.
+//
+//     Output:
+//
+//         goi18n writes the pseudo-localized translations of each pseudo-locale xx-yy to xx-yy.all.format.
+//
+//     Options:
+//
+//         -sourceLanguage tag
+//             goi18n pseudo-localizes the translations of this language.
+//             Default: en-us
+//
+//         -locales tags
+//             goi18n writes the translations of these comma separated pseudo-locales.
+//             Default: en-XA,ar-XB
+//
+//         -outdir directory
+//             goi18n writes the pseudo-localized translation files to this directory.
+//             Default: .
+//
+//         -format format
+//             goi18n encodes the pseudo-localized translation files in this format.
+//             Supported formats: json, toml, yaml, po, xliff12 (XLIFF 1.2), xliff20 (XLIFF 2.0)
+//             XLIFF files of both versions are written with the .xlf extension.
+//             Default: json
+//
+//         -flat
+//             goi18n writes the pseudo-localized translation files in flat format.
+//             Usage of '-format toml' automitically sets this flag.
+//             Default: true
+//
 package main
@@ -0,0 +1,662 @@
+package main
+
+import (
+	"encoding"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nicksnyder/go-i18n/i18n/bundle"
+	"github.com/nicksnyder/go-i18n/i18n/language"
+	"github.com/nicksnyder/go-i18n/i18n/translation"
+)
+
+type extractCommand struct {
+	paths          []string
+	sourceLanguage string
+	outdir         string
+	format         string
+	flat           bool
+
+	// unresolved holds the positions of translate calls whose translation id
+	// could not be resolved statically.
+	unresolved []string
+}
+
+// extractedMessage is a translation id that is used in Go source.
+type extractedMessage struct {
+	context string
+	id      string
+	plural  bool
+
+	// defaultMessage is the first translation.Default that is passed with the translation id.
+	defaultMessage *translation.Default
+}
+
+// i18nPath is the import path of the i18n package, whose T function takes
+// a context.Context before the translation id.
+const i18nPath = "github.com/nicksnyder/go-i18n/i18n"
+
+// tfuncConstructors are the functions and methods that return a TranslateFunc
+// or a ContextTranslateFunc as their first result.
+var tfuncConstructors = map[string]bool{
+	"Tfunc":                true,
+	"MustTfunc":            true,
+	"TfuncAndLanguage":     true,
+	"MustTfuncAndLanguage": true,
+	"IdentityTfunc":        true,
+	"ContextTfunc":         true,
+	"MustContextTfunc":     true,
+}
+
+func (ec *extractCommand) execute() error {
+	if len(ec.paths) < 1 {
+		return fmt.Errorf("need at least one Go file or directory to extract translation ids from")
+	}
+	sourceLanguage := language.Parse(ec.sourceLanguage)
+	if sourceLanguage == nil {
+		return fmt.Errorf("invalid source locale: %s", ec.sourceLanguage)
+	}
+	lang := sourceLanguage[0]
+
+	fset := token.NewFileSet()
+	files, err := parseGoFiles(fset, ec.paths)
+	if err != nil {
+		return err
+	}
+	e := &extractor{fset: fset, constants: make(map[string]map[string]string), messages: make(map[string]*extractedMessage)}
+	for _, f := range files {
+		e.collectConstants(f)
+	}
+	for _, f := range files {
+		e.extract(f)
+	}
+	ec.unresolved = e.unresolved
+	for _, pos := range ec.unresolved {
+		fmt.Printf("%s: could not resolve translation id\n", pos)
+	}
+
+	filename := filepath.Join(ec.outdir, fmt.Sprintf("%s.all.%s", lang.Tag, ec.format))
+	b := bundle.New()
+	if _, err := os.Stat(filename); err == nil {
+		if err := b.LoadTranslationFile(filename); err != nil {
+			return fmt.Errorf("failed to load translation file %s: %s", filename, err)
+		}
+	}
+	translations := b.Translations()[lang.Tag]
+	if translations == nil {
+		translations = make(map[string]translation.Translation)
+	}
+	for _, conflict := range e.conflicts {
+		fmt.Printf("%s: default message differs from an earlier default message of the same translation id\n", conflict)
+	}
+	for key, m := range e.messages {
+		existing := translations[key]
+		if existing != nil && m.plural && isSingle(existing) {
+			fmt.Printf("%s is used as a plural translation but it is not plural in %s\n", key, filename)
+		}
+		if existing != nil && (m.defaultMessage == nil || !existing.Incomplete(lang)) {
+			continue
+		}
+		t, err := m.translation()
+		if err != nil {
+			return fmt.Errorf("invalid default message of %s: %s", key, err)
+		}
+		t = t.Normalize(lang)
+		if existing != nil {
+			// The default message fills the untranslated templates of the existing translation.
+			t = t.Merge(existing)
+		}
+		translations[key] = t
+	}
+
+	all := filter(translations, func(t translation.Translation) translation.Translation {
+		return t
+	})
+	sort.Sort(translation.SortableByID(all))
+	var v interface{}
+	if ec.flat {
+		v = marshalFlatInterface(all)
+	} else {
+		v = marshalInterface(all)
+	}
+	buf, err := bundle.Marshal(ec.format, v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s strings to %s: %s", lang.Tag, ec.format, err)
+	}
+	if err := ioutil.WriteFile(filename, buf, 0666); err != nil {
+		return fmt.Errorf("failed to write %s: %s", filename, err)
+	}
+	return nil
+}
+
+// isSingle reports whether t is a non-plural translation.
+func isSingle(t translation.Translation) bool {
+	data, ok := t.MarshalInterface().(map[string]interface{})
+	if !ok {
+		return false
+	}
+	_, isText := data["translation"].(encoding.TextMarshaler)
+	return isText
+}
+
+func (m *extractedMessage) translation() (translation.Translation, error) {
+	var d translation.Default
+	if m.defaultMessage != nil {
+		d = *m.defaultMessage
+	}
+	if d.Plural() {
+		return d.Translation(m.context, m.id)
+	}
+	data := map[string]interface{}{"id": m.id, "translation": d.Other}
+	if m.context != "" {
+		data["context"] = m.context
+	}
+	if m.plural {
+		data["translation"] = map[string]interface{}{"other": d.Other}
+	}
+	return translation.NewTranslation(data)
+}
+
+func (ec *extractCommand) parse(arguments []string) {
+	flags := flag.NewFlagSet("extract", flag.ExitOnError)
+	flags.Usage = usageExtract
+
+	sourceLanguage := flags.String("sourceLanguage", "en-us", "")
+	outdir := flags.String("outdir", ".", "")
+	format := flags.String("format", "json", "")
+	flat := flags.Bool("flat", true, "")
+
+	flags.Parse(arguments)
+
+	ec.paths = flags.Args()
+	ec.sourceLanguage = *sourceLanguage
+	ec.outdir = *outdir
+	ec.format = *format
+	ec.flat = *flat || *format == "toml"
+}
+
+func (ec *extractCommand) SetArgs(args []string) {
+	ec.paths = args
+}
+
+// parseGoFiles parses the Go files in paths.
+// A directory is searched recursively for Go files, skipping testdata and vendor directories.
+// A path ending in "/..." is the same as the directory.
+func parseGoFiles(fset *token.FileSet, paths []string) ([]*ast.File, error) {
+	var files []*ast.File
+	for _, path := range paths {
+		path = strings.TrimSuffix(path, "/...")
+		err := filepath.Walk(path, func(name string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			base := info.Name()
+			if info.IsDir() {
+				if name != path && (base == "testdata" || base == "vendor" || strings.HasPrefix(base, ".") || strings.HasPrefix(base, "_")) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !strings.HasSuffix(base, ".go") {
+				return nil
+			}
+			f, err := parser.ParseFile(fset, name, nil, 0)
+			if err != nil {
+				return err
+			}
+			files = append(files, f)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// extractor finds the translation ids of translate calls in Go files.
+//
+// It works on the syntax of the files only, so it recognizes translate functions by name:
+// variables that are assigned the result of a Tfunc constructor (e.g. T, _ := i18n.Tfunc("en-US")),
+// variables, parameters and struct fields whose type is a TranslateFunc or a ContextTranslateFunc,
+// and the T function of the i18n package (e.g. i18n.T(ctx, "program_greeting")).
+type extractor struct {
+	fset *token.FileSet
+
+	// constants are the string constants of each package by package name and constant name.
+	constants map[string]map[string]string
+
+	// messages are the extracted messages by translation key.
+	messages map[string]*extractedMessage
+
+	unresolved []string
+
+	// conflicts holds the positions of default messages that differ from
+	// an earlier default message of the same translation id.
+	conflicts []string
+}
+
+func (e *extractor) collectConstants(f *ast.File) {
+	pkg := f.Name.Name
+	if e.constants[pkg] == nil {
+		e.constants[pkg] = make(map[string]string)
+	}
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs := spec.(*ast.ValueSpec)
+			for i, name := range vs.Names {
+				if i < len(vs.Values) {
+					if s, ok := e.stringValue(pkg, nil, vs.Values[i]); ok {
+						e.constants[pkg][name.Name] = s
+					}
+				}
+			}
+		}
+	}
+}
+
+// tfuncNames are the names of the translate functions in a file.
+// The value is true for context translate functions, which take a context before the translation id.
+type tfuncNames map[string]bool
+
+func (e *extractor) extract(f *ast.File) {
+	pkg := f.Name.Name
+	imports := make(map[string]string)
+	for _, imp := range f.Imports {
+		path, _ := strconv.Unquote(imp.Path.Value)
+		name := filepath.Base(path)
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		imports[name] = path
+	}
+
+	names := make(tfuncNames)
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if len(n.Rhs) == 1 {
+				if isContext, ok := tfuncConstructor(n.Rhs[0]); ok {
+					if ident, ok := n.Lhs[0].(*ast.Ident); ok {
+						names[ident.Name] = isContext
+					}
+				}
+			}
+		case *ast.ValueSpec:
+			if isContext, ok := tfuncType(n.Type); ok {
+				for _, name := range n.Names {
+					names[name.Name] = isContext
+				}
+			} else if len(n.Values) == 1 && len(n.Names) > 0 {
+				if isContext, ok := tfuncConstructor(n.Values[0]); ok {
+					names[n.Names[0].Name] = isContext
+				}
+			}
+		case *ast.Field:
+			if isContext, ok := tfuncType(n.Type); ok {
+				for _, name := range n.Names {
+					names[name.Name] = isContext
+				}
+			}
+		}
+		return true
+	})
+
+	ast.Inspect(f, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		isContext, ok := false, false
+		switch fun := call.Fun.(type) {
+		case *ast.Ident:
+			isContext, ok = names[fun.Name]
+		case *ast.SelectorExpr:
+			if x, isIdent := fun.X.(*ast.Ident); isIdent && fun.Sel.Name == "T" && imports[x.Name] == i18nPath {
+				// i18n.T(ctx, "program_greeting")
+				if len(call.Args) > 0 {
+					e.extractCall(pkg, imports, call.Args[1:], false)
+				}
+				return true
+			}
+			isContext, ok = names[fun.Sel.Name]
+		case *ast.CallExpr:
+			// e.g. i18n.MustTfunc("en-US")("program_greeting")
+			isContext, ok = tfuncConstructor(fun)
+		}
+		if ok {
+			e.extractCall(pkg, imports, call.Args, isContext)
+		}
+		return true
+	})
+}
+
+// extractCall extracts the message of the arguments of a translate call.
+func (e *extractor) extractCall(pkg string, imports map[string]string, args []ast.Expr, isContext bool) {
+	context := ""
+	if isContext {
+		if len(args) < 1 {
+			return
+		}
+		var ok bool
+		if context, ok = e.stringValue(pkg, imports, args[0]); !ok {
+			e.unresolved = append(e.unresolved, e.fset.Position(args[0].Pos()).String())
+			return
+		}
+		args = args[1:]
+	}
+	if len(args) < 1 {
+		return
+	}
+	id, ok := e.stringValue(pkg, imports, args[0])
+	if !ok {
+		e.unresolved = append(e.unresolved, e.fset.Position(args[0].Pos()).String())
+		return
+	}
+	if !isContext {
+		// The ids of the constants generated by goi18n constants include the context.
+		context, id = translation.SplitKey(id)
+	}
+	key := translation.Key(context, id)
+	m := e.messages[key]
+	if m == nil {
+		m = &extractedMessage{context: context, id: id}
+		e.messages[key] = m
+	}
+	args = args[1:]
+	if len(args) > 0 {
+		if d, ok := e.defaultMessage(pkg, imports, args[0]); ok {
+			if m.defaultMessage == nil {
+				m.defaultMessage = &d
+			} else if *m.defaultMessage != d {
+				e.conflicts = append(e.conflicts, e.fset.Position(args[0].Pos()).String())
+			}
+			if d.Plural() {
+				m.plural = true
+			}
+			args = args[1:]
+		}
+	}
+	if isPluralCall(args) {
+		m.plural = true
+	}
+}
+
+// defaultMessage returns the value of expr if it is a translation.Default composite literal.
+// Messages that are not string literals or string constants are left empty.
+func (e *extractor) defaultMessage(pkg string, imports map[string]string, expr ast.Expr) (translation.Default, bool) {
+	var d translation.Default
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return d, false
+	}
+	switch t := lit.Type.(type) {
+	case *ast.Ident:
+		ok = t.Name == "Default"
+	case *ast.SelectorExpr:
+		ok = t.Sel.Name == "Default"
+	default:
+		ok = false
+	}
+	if !ok {
+		return d, false
+	}
+	messages := map[string]*string{
+		"Zero":  &d.Zero,
+		"One":   &d.One,
+		"Two":   &d.Two,
+		"Few":   &d.Few,
+		"Many":  &d.Many,
+		"Other": &d.Other,
+	}
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok || messages[key.Name] == nil {
+			continue
+		}
+		if s, ok := e.stringValue(pkg, imports, kv.Value); ok {
+			*messages[key.Name] = s
+		}
+	}
+	return d, true
+}
+
+// stringValue returns the value of expr if it is a string literal, a string constant
+// or a concatenation of them.
+func (e *extractor) stringValue(pkg string, imports map[string]string, expr ast.Expr) (string, bool) {
+	switch expr := expr.(type) {
+	case *ast.BasicLit:
+		if expr.Kind == token.STRING {
+			s, err := strconv.Unquote(expr.Value)
+			return s, err == nil
+		}
+	case *ast.ParenExpr:
+		return e.stringValue(pkg, imports, expr.X)
+	case *ast.BinaryExpr:
+		if expr.Op == token.ADD {
+			x, ok := e.stringValue(pkg, imports, expr.X)
+			if !ok {
+				return "", false
+			}
+			y, ok := e.stringValue(pkg, imports, expr.Y)
+			return x + y, ok
+		}
+	case *ast.Ident:
+		s, ok := e.constants[pkg][expr.Name]
+		return s, ok
+	case *ast.SelectorExpr:
+		if x, ok := expr.X.(*ast.Ident); ok {
+			if path, ok := imports[x.Name]; ok {
+				// The package name of an import is assumed to be the last element of its path.
+				s, ok := e.constants[filepath.Base(path)][expr.Sel.Name]
+				return s, ok
+			}
+		}
+	}
+	return "", false
+}
+
+// tfuncConstructor reports whether expr is a call of a Tfunc constructor,
+// and whether the constructor returns a ContextTranslateFunc.
+func tfuncConstructor(expr ast.Expr) (isContext, ok bool) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return false, false
+	}
+	var name string
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		name = fun.Name
+	case *ast.SelectorExpr:
+		name = fun.Sel.Name
+	}
+	return strings.Contains(name, "Context"), tfuncConstructors[name]
+}
+
+// tfuncType reports whether expr is the TranslateFunc or ContextTranslateFunc type
+// of the i18n or bundle package, and whether it is a ContextTranslateFunc.
+func tfuncType(expr ast.Expr) (isContext, ok bool) {
+	var name string
+	switch t := expr.(type) {
+	case *ast.Ident:
+		name = t.Name
+	case *ast.SelectorExpr:
+		name = t.Sel.Name
+	}
+	switch name {
+	case "TranslateFunc":
+		return false, true
+	case "ContextTranslateFunc":
+		return true, true
+	}
+	return false, false
+}
+
+// isPluralCall reports whether the arguments after the translation id
+// are those of a plural translation: a count or a language.Range,
+// optionally followed by template data, or template data with a Count field.
+func isPluralCall(args []ast.Expr) bool {
+	if len(args) == 0 {
+		return false
+	}
+	if isCount(args[0]) || hasCountField(args[0]) {
+		return true
+	}
+	return len(args) > 1 && hasCountField(args[1])
+}
+
+// isCount reports whether expr is a count: a number literal, a string literal of a number,
+// a variable or field named like a count (see isCountName), a len call,
+// a numeric conversion of a count, or a language.Range literal.
+func isCount(expr ast.Expr) bool {
+	switch expr := expr.(type) {
+	case *ast.BasicLit:
+		switch expr.Kind {
+		case token.INT, token.FLOAT:
+			return true
+		case token.STRING:
+			s, err := strconv.Unquote(expr.Value)
+			if err != nil {
+				return false
+			}
+			_, err = strconv.ParseFloat(s, 64)
+			return err == nil
+		}
+	case *ast.UnaryExpr:
+		return expr.Op == token.SUB && isCount(expr.X)
+	case *ast.ParenExpr:
+		return isCount(expr.X)
+	case *ast.Ident:
+		return isCountName(expr.Name)
+	case *ast.SelectorExpr:
+		return isCountName(expr.Sel.Name)
+	case *ast.CallExpr:
+		fun, ok := expr.Fun.(*ast.Ident)
+		if !ok || len(expr.Args) != 1 {
+			return false
+		}
+		switch fun.Name {
+		case "len":
+			return true
+		case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "float32", "float64":
+			return isCount(expr.Args[0])
+		}
+	case *ast.CompositeLit:
+		sel, ok := expr.Type.(*ast.SelectorExpr)
+		return ok && sel.Sel.Name == "Range"
+	}
+	return false
+}
+
+// hasCountField reports whether expr is a composite literal of template data with a Count field.
+func hasCountField(expr ast.Expr) bool {
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		expr = unary.X
+	}
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return false
+	}
+	for _, elt := range lit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			switch key := kv.Key.(type) {
+			case *ast.BasicLit:
+				if key.Value == `"Count"` {
+					return true
+				}
+			case *ast.Ident:
+				if key.Name == "Count" {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// isCountName reports whether name is the name of a variable or field that holds a count:
+// n, count, Count or a name that ends with Count (e.g. unreadCount).
+// Names that merely end with "count", such as account or discount, are not counts.
+func isCountName(name string) bool {
+	switch name {
+	case "n", "count", "Count":
+		return true
+	}
+	return len(name) > len("Count") && strings.HasSuffix(name, "Count")
+}
+
+func usageExtract() {
+	fmt.Printf(`Extract translation ids from Go source files.
+
+Usage:
+
+    goi18n extract [options] [paths...]
+
+Paths:
+
+    Each path is a Go file or a directory (e.g. ./...), which is searched recursively for
+    Go files. Directories named testdata and vendor are skipped.
+
+Extraction:
+
+    goi18n finds calls of translate functions: variables assigned the result of Tfunc,
+    MustTfunc, TfuncAndLanguage, MustTfuncAndLanguage and IdentityTfunc (ContextTfunc and
+    MustContextTfunc for context translate functions), variables, parameters and fields
+    of type TranslateFunc or ContextTranslateFunc, and i18n.T (e.g. i18n.T(ctx, "program_greeting")).
+
+    The translation id of a call must be a string literal, a string constant (such as the
+    constants generated by goi18n constants) or a concatenation of them. goi18n reports the
+    calls whose translation id can not be resolved.
+
+    A translation is plural if it is called with a count, a language.Range,
+    template data with a Count field, or a plural default message. A count is a
+    number, a len call, or a variable or field named n, count or *Count (e.g. unreadCount).
+
+    A translation.Default literal after the translation id is the default message
+    of the translation (e.g. T("page_title", translation.Default{Other: "Home"})).
+
+Output:
+
+    goi18n writes the translations to xx-yy.all.format for the source language xx-yy.
+    If that file exists, its translations are kept and the new translation ids are added
+    to it. New and untranslated translations get their default messages, if any.
+
+Options:
+
+    -sourceLanguage tag
+        goi18n writes the translations of this language.
+        Default: en-us
+
+    -outdir directory
+        goi18n reads and writes the translation file in this directory.
+        Default: .
+
+    -format format
+        goi18n encodes the translation file in this format.
+        Supported formats: json, toml, yaml
+        Default: json
+
+    -flat
+        goi18n writes the translation file in flat format.
+        Usage of '-format toml' automitically sets this flag.
+        Default: true
+
+`)
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+func TestExtractExecute(t *testing.T) {
+	resetDir(t, "testdata/output")
+	buf, err := ioutil.ReadFile("testdata/input/extract/en-us.all.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile("testdata/output/en-us.all.json", buf, 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	ec := &extractCommand{
+		paths:          []string{"testdata/input/extract/..."},
+		sourceLanguage: "en-us",
+		outdir:         "testdata/output",
+		format:         "json",
+		flat:           true,
+	}
+	if err := ec.execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	expectEqualFiles(t, "testdata/output/en-us.all.json", "testdata/expected/extract/en-us.all.json")
+	if expected := []string{"testdata/input/extract/main.go:37:16"}; !reflect.DeepEqual(ec.unresolved, expected) {
+		t.Errorf("unresolved = %v; expected %v", ec.unresolved, expected)
+	}
+}
+
+func TestIsCountName(t *testing.T) {
+	tests := map[string]bool{
+		"n":           true,
+		"count":       true,
+		"Count":       true,
+		"unreadCount": true,
+		"ItemCount":   true,
+		"account":     false,
+		"discount":    false,
+		"Discount":    false,
+		"name":        false,
+	}
+	for name, expected := range tests {
+		if actual := isCountName(name); actual != expected {
+			t.Errorf("isCountName(%q) = %v; expected %v", name, actual, expected)
+		}
+	}
+}
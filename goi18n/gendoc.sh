@@ -9,4 +9,9 @@ echo "//" >> doc.go
 goi18n | sed -e 's/^/\/\/     /' >> doc.go
 goi18n merge -help | sed -e 's/^/\/\/     /' >> doc.go
 goi18n constants -help | sed -e 's/^/\/\/     /' >> doc.go
+goi18n extract -help | sed -e 's/^/\/\/     /' >> doc.go
+goi18n lint -help | sed -e 's/^/\/\/     /' >> doc.go
+goi18n diff -help | sed -e 's/^/\/\/     /' >> doc.go
+goi18n stats -help | sed -e 's/^/\/\/     /' >> doc.go
+goi18n pseudo -help | sed -e 's/^/\/\/     /' >> doc.go
 echo "package main" >> doc.go
@@ -27,6 +27,21 @@ func main() {
 	case "constants":
 		cmd = &constantsCommand{}
 		cmd.parse(os.Args[2:])
+	case "extract":
+		cmd = &extractCommand{}
+		cmd.parse(os.Args[2:])
+	case "lint":
+		cmd = &lintCommand{}
+		cmd.parse(os.Args[2:])
+	case "diff":
+		cmd = &diffCommand{}
+		cmd.parse(os.Args[2:])
+	case "stats":
+		cmd = &statsCommand{}
+		cmd.parse(os.Args[2:])
+	case "pseudo":
+		cmd = &pseudoCommand{}
+		cmd.parse(os.Args[2:])
 	default:
 		cmd = &mergeCommand{}
 		cmd.parse(os.Args[1:])
@@ -45,6 +60,11 @@ Usage:
 
     goi18n merge     Merge translation files
     goi18n constants Generate constant file from translation file
+    goi18n extract   Extract translation ids from Go source files
+    goi18n lint      Check translation files for broken and suspicious translations
+    goi18n diff      Show the changes between two versions of translation files
+    goi18n stats     Report how complete the translations of each language are
+    goi18n pseudo    Generate pseudo-localized translation files
 
 For more details execute:
 
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	"github.com/nicksnyder/go-i18n/i18n/bundle"
+	"github.com/nicksnyder/go-i18n/i18n/language"
+	"github.com/nicksnyder/go-i18n/i18n/translation"
+)
+
+type lintCommand struct {
+	translationFiles []string
+	sourceLanguage   string
+
+	// problems holds the problems found by the last execute, sorted.
+	problems []string
+}
+
+func (lc *lintCommand) execute() error {
+	if len(lc.translationFiles) < 1 {
+		return fmt.Errorf("need at least one translation file to lint")
+	}
+
+	if lang := language.Parse(lc.sourceLanguage); lang == nil {
+		return fmt.Errorf("invalid source locale: %s", lc.sourceLanguage)
+	}
+
+	lc.problems = nil
+	bundle := bundle.New()
+	for _, tf := range lc.translationFiles {
+		lc.loadTranslationFile(bundle, tf)
+	}
+
+	translations := bundle.Translations()
+	sourceLanguageTag := language.NormalizeTag(lc.sourceLanguage)
+	sourceTranslations := translations[sourceLanguageTag]
+	for localeID, localeTranslations := range translations {
+		lang := language.MustParse(localeID)[0]
+		for key, t := range localeTranslations {
+			for _, problem := range lintPlurals(lang, t) {
+				lc.report(localeID, t, problem)
+			}
+			src := sourceTranslations[key]
+			// Untranslated translations are expected to be missing the variables of the source translation.
+			if localeID == sourceLanguageTag || src == nil || t.Incomplete(lang) {
+				continue
+			}
+			missing, extra := diffStrings(translation.Variables(src), translation.Variables(t))
+			for _, v := range missing {
+				lc.report(localeID, t, fmt.Sprintf("missing variable %q of the source translation", v))
+			}
+			for _, v := range extra {
+				lc.report(localeID, t, fmt.Sprintf("variable %q is not in the source translation", v))
+			}
+		}
+	}
+
+	sort.Strings(lc.problems)
+	for _, problem := range lc.problems {
+		fmt.Println(problem)
+	}
+	if len(lc.problems) > 0 {
+		return fmt.Errorf("found %d problem(s)", len(lc.problems))
+	}
+	return nil
+}
+
+// loadTranslationFile adds the translations of filename that parse to b,
+// and reports the translations that fail to parse.
+func (lc *lintCommand) loadTranslationFile(b *bundle.Bundle, filename string) {
+	buf, err := ioutil.ReadFile(filename)
+	if err != nil {
+		lc.problems = append(lc.problems, fmt.Sprintf("%s: %s", filename, err))
+		return
+	}
+	lang, translations, err := bundle.ParseTranslationFile(filename, buf)
+	if lang == nil {
+		lc.problems = append(lc.problems, fmt.Sprintf("%s: %s", filename, err))
+		return
+	}
+	b.AddTranslation(lang, translations...)
+	if errs, ok := err.(bundle.TranslationErrors); ok {
+		for _, te := range errs {
+			id := te.ID
+			if te.Context != "" {
+				id = fmt.Sprintf("%s (context %q)", id, te.Context)
+			}
+			lc.problems = append(lc.problems, fmt.Sprintf("%s: %s: invalid translation: %s", lang.Tag, id, te.Err))
+		}
+	}
+}
+
+// report adds a problem with translation t of localeID.
+func (lc *lintCommand) report(localeID string, t translation.Translation, problem string) {
+	id := t.ID()
+	if context := t.Metadata().Context; context != "" {
+		id = fmt.Sprintf("%s (context %q)", id, context)
+	}
+	lc.problems = append(lc.problems, fmt.Sprintf("%s: %s: %s", localeID, id, problem))
+}
+
+// lintPlurals returns the plural categories of t that lang does not have
+// and the plural categories of lang that t is missing.
+func lintPlurals(lang *language.Language, t translation.Translation) []string {
+	if s, ok := t.(translation.Selector); ok {
+		var problems []string
+		for value, variant := range s.Variants() {
+			for _, problem := range lintPlurals(lang, variant) {
+				problems = append(problems, fmt.Sprintf("variant %q: %s", value, problem))
+			}
+		}
+		return problems
+	}
+
+	plurals := translation.Plurals(t)
+	if plurals == nil {
+		return nil
+	}
+	kind := "plural"
+	langPlurals := lang.SortedPlurals()
+	if t.Ordinal() {
+		kind = "ordinal"
+		langPlurals = lang.OrdinalSpec().SortedPlurals()
+	}
+	var problems []string
+	unused, missing := diffStrings(pluralStrings(plurals), pluralStrings(langPlurals))
+	for _, pc := range unused {
+		problems = append(problems, fmt.Sprintf("%s has no %s category %q", lang, kind, pc))
+	}
+	for _, pc := range missing {
+		problems = append(problems, fmt.Sprintf("missing %s category %q", kind, pc))
+	}
+	return problems
+}
+
+func pluralStrings(plurals []language.Plural) []string {
+	s := make([]string, len(plurals))
+	for i, pc := range plurals {
+		s[i] = string(pc)
+	}
+	return s
+}
+
+// diffStrings returns the strings that are only in a and the strings that are only in b.
+func diffStrings(a, b []string) (onlyA, onlyB []string) {
+	inA := make(map[string]bool, len(a))
+	for _, s := range a {
+		inA[s] = true
+	}
+	inB := make(map[string]bool, len(b))
+	for _, s := range b {
+		inB[s] = true
+		if !inA[s] {
+			onlyB = append(onlyB, s)
+		}
+	}
+	for _, s := range a {
+		if !inB[s] {
+			onlyA = append(onlyA, s)
+		}
+	}
+	return onlyA, onlyB
+}
+
+func (lc *lintCommand) parse(arguments []string) {
+	flags := flag.NewFlagSet("lint", flag.ExitOnError)
+	flags.Usage = usageLint
+
+	sourceLanguage := flags.String("sourceLanguage", "en-us", "")
+
+	flags.Parse(arguments)
+
+	lc.translationFiles = flags.Args()
+	lc.sourceLanguage = *sourceLanguage
+}
+
+func (lc *lintCommand) SetArgs(args []string) {
+	lc.translationFiles = args
+}
+
+func usageLint() {
+	fmt.Printf(`Check translation files for broken and suspicious translations.
+
+Usage:
+
+    goi18n lint [options] [files...]
+
+Translation files:
+
+    A translation file contains the strings and translations for a single language.
+
+    Translation file names must have a suffix of a supported format (e.g. .json) and
+    contain a valid language tag as defined by RFC 5646 (e.g. en-us, fr, zh-hant, etc.).
+
+Problems:
+
+    goi18n reports:
+      - translation files that fail to load.
+      - translations whose template fails to parse. The other translations of the file
+        are still checked.
+      - template variables (e.g. {{.Person}}) of a source translation that a translation is
+        missing, and variables of a translation that the source translation does not have.
+        Untranslated translations are not checked.
+      - plural categories of a translation that its language does not have.
+      - plural categories of its language that a plural translation is missing.
+
+    goi18n exits with status 1 if it finds a problem, so it can be used in continuous integration.
+
+Options:
+
+    -sourceLanguage tag
+        Translations are compared with the translations of this language.
+        Default: en-us
+
+`)
+}
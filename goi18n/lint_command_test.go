@@ -0,0 +1,47 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLintExecute(t *testing.T) {
+	lc := &lintCommand{
+		translationFiles: []string{
+			"testdata/input/lint/en-us.json",
+			"testdata/input/lint/de-de.json",
+			"testdata/input/lint/fr-fr.json",
+		},
+		sourceLanguage: "en-us",
+	}
+	if err := lc.execute(); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	expected := []string{
+		`de-de: files: de-de has no plural category "few"`,
+		`de-de: files: missing plural category "one"`,
+		`de-de: hello: missing variable "Person" of the source translation`,
+		`de-de: hello: variable "Name" is not in the source translation`,
+		`de-de: invited: variable "Party" is not in the source translation`,
+	}
+	if len(lc.problems) != len(expected)+2 {
+		t.Fatalf("problems = %q; expected %q and problems for fr-fr", lc.problems, expected)
+	}
+	if !reflect.DeepEqual(lc.problems[:len(expected)], expected) {
+		t.Errorf("problems = %q; expected %q", lc.problems[:len(expected)], expected)
+	}
+	// The template error of hello does not stop the other translations of fr-fr from being checked.
+	if parseProblem := lc.problems[len(expected)]; !strings.HasPrefix(parseProblem, "fr-fr: hello: invalid translation: ") {
+		t.Errorf("expected a template error for hello but got %q", parseProblem)
+	}
+	if problem, expected := lc.problems[len(expected)+1], `fr-fr: invited: variable "Party" is not in the source translation`; problem != expected {
+		t.Errorf("problem = %q; expected %q", problem, expected)
+	}
+
+	lc.translationFiles = lc.translationFiles[:1]
+	if err := lc.execute(); err != nil {
+		t.Errorf("expected no problems in the source file but got %q", lc.problems)
+	}
+}
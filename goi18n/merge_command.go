@@ -1,7 +1,6 @@
 package main
 
 import (
-	"encoding/json"
 	"flag"
 	"fmt"
 	"io/ioutil"
@@ -9,12 +8,9 @@ import (
 	"reflect"
 	"sort"
 
-	"gopkg.in/yaml.v2"
-
 	"github.com/nicksnyder/go-i18n/i18n/bundle"
 	"github.com/nicksnyder/go-i18n/i18n/language"
 	"github.com/nicksnyder/go-i18n/i18n/translation"
-	toml "github.com/pelletier/go-toml"
 )
 
 type mergeCommand struct {
@@ -49,8 +45,11 @@ func (mc *mergeCommand) execute() error {
 	}
 	for translationID, src := range sourceTranslations {
 		for _, localeTranslations := range translations {
-			if dst := localeTranslations[translationID]; dst == nil || reflect.TypeOf(src) != reflect.TypeOf(dst) {
+			if dst := localeTranslations[translationID]; dst == nil || !sameKind(src, dst) {
 				localeTranslations[translationID] = src.UntranslatedCopy()
+			} else {
+				// Every language has the metadata and the select variants of the source translation.
+				localeTranslations[translationID] = src.UntranslatedCopy().Merge(dst)
 			}
 		}
 	}
@@ -60,23 +59,49 @@ func (mc *mergeCommand) execute() error {
 		all := filter(localeTranslations, func(t translation.Translation) translation.Translation {
 			return t.Normalize(lang)
 		})
-		if err := mc.writeFile("all", all, localeID); err != nil {
+		if err := mc.writeFile("all", all, localeID, sourceTranslations); err != nil {
 			return err
 		}
 
 		untranslated := filter(localeTranslations, func(t translation.Translation) translation.Translation {
 			if t.Incomplete(lang) {
-				return t.Normalize(lang).Backfill(sourceTranslations[t.ID()])
+				if mc.bilingual() {
+					// The source translation is written next to the empty translation.
+					return t.Normalize(lang)
+				}
+				return t.Normalize(lang).Backfill(sourceTranslations[translation.KeyOf(t)])
 			}
 			return nil
 		})
-		if err := mc.writeFile("untranslated", untranslated, localeID); err != nil {
+		if err := mc.writeFile("untranslated", untranslated, localeID, sourceTranslations); err != nil {
+			return err
+		}
+	}
+
+	if mc.format == "po" {
+		sourceLanguage := language.MustParse(sourceLanguageTag)[0]
+		template := filter(sourceTranslations, func(t translation.Translation) translation.Translation {
+			return t.UntranslatedCopy().Normalize(sourceLanguage)
+		})
+		filename := filepath.Join(mc.outdir, sourceLanguageTag+".pot")
+		if err := mc.writeTranslations(filename, "pot", template, "", sourceTranslations); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// sameKind reports whether dst can hold the translation of src.
+func sameKind(src, dst translation.Translation) bool {
+	if reflect.TypeOf(src) != reflect.TypeOf(dst) || src.Ordinal() != dst.Ordinal() {
+		return false
+	}
+	if s, ok := src.(translation.Selector); ok {
+		return s.SelectKey() == dst.(translation.Selector).SelectKey()
+	}
+	return true
+}
+
 func (mc *mergeCommand) parse(arguments []string) {
 	flags := flag.NewFlagSet("merge", flag.ExitOnError)
 	flags.Usage = usageMerge
@@ -92,9 +117,12 @@ func (mc *mergeCommand) parse(arguments []string) {
 	mc.sourceLanguage = *sourceLanguage
 	mc.outdir = *outdir
 	mc.format = *format
-	if *format == "toml" {
+	switch *format {
+	case "toml":
 		mc.flat = true
-	} else {
+	case "po", "xliff12", "xliff20":
+		mc.flat = false
+	default:
 		mc.flat = *flat
 	}
 }
@@ -103,29 +131,91 @@ func (mc *mergeCommand) SetArgs(args []string) {
 	mc.translationFiles = args
 }
 
-func (mc *mergeCommand) writeFile(label string, translations []translation.Translation, localeID string) error {
+// bilingual reports whether the output format stores the source translations
+// next to the translations, in which case untranslated files are not backfilled.
+func (mc *mergeCommand) bilingual() bool {
+	switch mc.format {
+	case "po", "xliff12", "xliff20":
+		return true
+	}
+	return false
+}
+
+// formatExt returns the file extension of format.
+// The files of both XLIFF versions have the extension most tools use, .xlf.
+func formatExt(format string) string {
+	switch format {
+	case "xliff12", "xliff20":
+		return "xlf"
+	}
+	return format
+}
+
+// marshal encodes v in format.
+func marshal(format string, v interface{}) ([]byte, error) {
+	switch format {
+	case "xliff12":
+		return bundle.MarshalXLIFF(bundle.XLIFF12, v)
+	case "xliff20":
+		return bundle.MarshalXLIFF(bundle.XLIFF20, v)
+	}
+	return bundle.Marshal(format, v)
+}
+
+func (mc *mergeCommand) writeFile(label string, translations []translation.Translation, localeID string, sourceTranslations map[string]translation.Translation) error {
+	filename := filepath.Join(mc.outdir, fmt.Sprintf("%s.%s.%s", localeID, label, formatExt(mc.format)))
+	if localeID == language.NormalizeTag(mc.sourceLanguage) {
+		// The translations of the source language are their own source.
+		sourceTranslations = nil
+	}
+	return mc.writeTranslations(filename, mc.format, translations, localeID, sourceTranslations)
+}
+
+func (mc *mergeCommand) writeTranslations(filename, format string, translations []translation.Translation, localeID string, sourceTranslations map[string]translation.Translation) error {
 	sort.Sort(translation.SortableByID(translations))
+	if format == "po" || format == "pot" {
+		translations = withoutOrdinals(filename, translations)
+	}
 
-	var convert func([]translation.Translation) interface{}
-	if mc.flat {
-		convert = marshalFlatInterface
-	} else {
-		convert = marshalInterface
+	var v interface{}
+	switch {
+	case mc.bilingual():
+		v = &bundle.Catalog{
+			Language:       localeID,
+			SourceLanguage: language.NormalizeTag(mc.sourceLanguage),
+			Translations:   marshalSourceInterface(translations, sourceTranslations),
+		}
+	case mc.flat:
+		v = marshalFlatInterface(translations)
+	default:
+		v = marshalInterface(translations)
 	}
 
-	buf, err := mc.marshal(convert(translations))
+	buf, err := marshal(format, v)
 	if err != nil {
-		return fmt.Errorf("failed to marshal %s strings to %s: %s", localeID, mc.format, err)
+		return fmt.Errorf("failed to marshal %s strings to %s: %s", localeID, format, err)
 	}
 
-	filename := filepath.Join(mc.outdir, fmt.Sprintf("%s.%s.%s", localeID, label, mc.format))
-
 	if err := ioutil.WriteFile(filename, buf, 0666); err != nil {
 		return fmt.Errorf("failed to write %s: %s", filename, err)
 	}
 	return nil
 }
 
+// withoutOrdinals returns translations without their ordinal translations,
+// which gettext cannot represent, and warns that those are not written to filename.
+func withoutOrdinals(filename string, translations []translation.Translation) []translation.Translation {
+	kept := make([]translation.Translation, 0, len(translations))
+	for _, t := range translations {
+		if t.Ordinal() {
+			fmt.Printf("warning: %s does not contain %s because PO does not support ordinal translations\n", filename, translation.KeyOf(t))
+			continue
+		}
+		kept = append(kept, t)
+	}
+	return kept
+}
+
 func filter(translations map[string]translation.Translation, f func(translation.Translation) translation.Translation) []translation.Translation {
 	filtered := make([]translation.Translation, 0, len(translations))
 	for _, translation := range translations {
@@ -139,43 +229,32 @@ func filter(translations map[string]translation.Translation, f func(translation.
 
 func marshalFlatInterface(translations []translation.Translation) interface{} {
 	mi := make(map[string]interface{}, len(translations))
-	for _, translation := range translations {
-		mi[translation.ID()] = translation.MarshalFlatInterface()
+	for _, t := range translations {
+		mi[translation.KeyOf(t)] = t.MarshalFlatInterface()
 	}
 	return mi
 }
 
-func marshalInterface(translations []translation.Translation) interface{} {
+// marshalSourceInterface is similar to marshalInterface
+// except it adds the source translation of each translation under the "source" key.
+func marshalSourceInterface(translations []translation.Translation, sourceTranslations map[string]translation.Translation) []interface{} {
 	mi := make([]interface{}, len(translations))
-	for i, translation := range translations {
-		mi[i] = translation.MarshalInterface()
+	for i, t := range translations {
+		data := t.MarshalInterface().(map[string]interface{})
+		if src := sourceTranslations[translation.KeyOf(t)]; src != nil {
+			data["source"] = src.MarshalInterface().(map[string]interface{})["translation"]
+		}
+		mi[i] = data
 	}
 	return mi
 }
 
-func (mc mergeCommand) marshal(v interface{}) ([]byte, error) {
-	switch mc.format {
-	case "json":
-		return json.MarshalIndent(v, "", "  ")
-	case "toml":
-		return marshalTOML(v)
-	case "yaml":
-		return yaml.Marshal(v)
-	}
-	return nil, fmt.Errorf("unsupported format: %s\n", mc.format)
-}
-
-func marshalTOML(v interface{}) ([]byte, error) {
-	m, ok := v.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid format for marshaling to TOML")
-	}
-	tree, err := toml.TreeFromMap(m)
-	if err != nil {
-		return nil, err
+func marshalInterface(translations []translation.Translation) interface{} {
+	mi := make([]interface{}, len(translations))
+	for i, translation := range translations {
+		mi[i] = translation.MarshalInterface()
 	}
-	s, err := tree.ToTomlString()
-	return []byte(s), err
+	return mi
 }
 
 func usageMerge() {
@@ -203,6 +282,13 @@ Translation files:
             The translations for the strings in this file will be extracted from the source language.
             After they are translated, merge them back into xx-yy.all.format using goi18n.
 
+    When the format is po, xliff12 or xliff20, the untranslated strings are left empty and the
+    source language strings are written next to them instead.
+    When the format is po, goi18n also produces a gettext template:
+
+        xx-yy.pot
+            This file contains all strings of the source language xx-yy with empty translations.
+
 Merging:
 
     goi18n will merge multiple translation files for the same language.
@@ -210,6 +296,9 @@ Merging:
     Non-empty fields in the duplicate translation will overwrite those fields in the existing translation.
     Empty fields in the duplicate translation are ignored.
 
+    The description, context, maxLength and html flag of a source language translation are copied to
+    the translations of the other languages unless those translations have their own.
+
 Adding a new language:
 
     To produce translation files for a new language, create an empty translation file with the
@@ -227,12 +316,14 @@ Options:
 
     -format format
         goi18n encodes the output translation files in this format.
-        Supported formats: json, toml, yaml
+        Supported formats: json, toml, yaml, po, xliff12 (XLIFF 1.2), xliff20 (XLIFF 2.0)
+        XLIFF files of both versions are written with the .xlf extension.
         Default: json
 
     -flat
         goi18n writes the output translation files in flat format.
         Usage of '-format toml' automitically sets this flag.
+        It is ignored for '-format po', '-format xliff12' and '-format xliff20'.
         Default: true
 
 `)
@@ -0,0 +1,31 @@
+package main
+
+import "testing"
+
+func TestMergeExecuteContext(t *testing.T) {
+	files := []string{
+		"testdata/input/context/en-us.json",
+		"testdata/input/context/de-de.json",
+	}
+	for _, format := range []string{"json", "po"} {
+		outdir := "testdata/output/context/" + format
+		expectedDir := "testdata/expected/context/" + format
+		resetDir(t, "testdata/output/context")
+		resetDir(t, outdir)
+
+		mc := &mergeCommand{
+			translationFiles: files,
+			sourceLanguage:   "en-us",
+			outdir:           outdir,
+			format:           format,
+			flat:             true,
+		}
+		if err := mc.execute(); err != nil {
+			t.Fatal(err)
+		}
+
+		expectEqualFiles(t, outdir+"/en-us.all."+format, expectedDir+"/en-us.all."+format)
+		expectEqualFiles(t, outdir+"/de-de.all."+format, expectedDir+"/de-de.all."+format)
+		expectEqualFiles(t, outdir+"/de-de.untranslated."+format, expectedDir+"/de-de.untranslated."+format)
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestMergeExecutePO(t *testing.T) {
+	resetDir(t, "testdata/output/po")
+
+	mc := &mergeCommand{
+		translationFiles: []string{
+			"testdata/input/en-us.one.json",
+			"testdata/input/en-us.two.json",
+			"testdata/input/fr-fr.json",
+			"testdata/input/ar-ar.one.json",
+			"testdata/input/ar-ar.two.json",
+		},
+		sourceLanguage: "en-us",
+		outdir:         "testdata/output/po",
+		format:         "po",
+	}
+	if err := mc.execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	expectEqualFiles(t, "testdata/output/po/en-us.all.po", "testdata/expected/po/en-us.all.po")
+	expectEqualFiles(t, "testdata/output/po/ar-ar.all.po", "testdata/expected/po/ar-ar.all.po")
+	expectEqualFiles(t, "testdata/output/po/en-us.untranslated.po", "testdata/expected/po/en-us.untranslated.po")
+	expectEqualFiles(t, "testdata/output/po/ar-ar.untranslated.po", "testdata/expected/po/ar-ar.untranslated.po")
+	expectEqualFiles(t, "testdata/output/po/en-us.pot", "testdata/expected/po/en-us.pot")
+}
+
+func TestMergeExecutePORoundTrip(t *testing.T) {
+	resetDir(t, "testdata/output/po")
+
+	mc := &mergeCommand{
+		translationFiles: []string{
+			"testdata/input/po/en-us.all.json",
+			"testdata/input/po/ar-ar.all.po",
+		},
+		sourceLanguage: "en-us",
+		outdir:         "testdata/output/po",
+		format:         "json",
+		flat:           false,
+	}
+	if err := mc.execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	expectEqualFiles(t, "testdata/output/po/ar-ar.all.json", "testdata/expected/ar-ar.all.json")
+}
+
+func TestMergeExecutePOOrdinal(t *testing.T) {
+	resetDir(t, "testdata/output/po")
+
+	mc := &mergeCommand{
+		translationFiles: []string{"testdata/input/po/en-us.ordinal.json"},
+		sourceLanguage:   "en-us",
+		outdir:           "testdata/output/po",
+		format:           "po",
+	}
+	if err := mc.execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	buf, err := ioutil.ReadFile("testdata/output/po/en-us.all.po")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if po := string(buf); strings.Contains(po, `msgid "place"`) || !strings.Contains(po, `msgid "files"`) {
+		t.Errorf("expected the ordinal translation to be skipped; got\n%s", po)
+	}
+}
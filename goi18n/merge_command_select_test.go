@@ -0,0 +1,35 @@
+package main
+
+import "testing"
+
+func TestMergeExecuteSelect(t *testing.T) {
+	files := []string{
+		"testdata/input/select/en-us.json",
+		"testdata/input/select/fr-fr.json",
+	}
+	for _, flat := range []bool{false, true} {
+		outdir := "testdata/output/select"
+		expectedDir := "testdata/expected/select"
+		if flat {
+			outdir += "/flat"
+			expectedDir += "/flat"
+		}
+		resetDir(t, outdir)
+
+		mc := &mergeCommand{
+			translationFiles: files,
+			sourceLanguage:   "en-us",
+			outdir:           outdir,
+			format:           "json",
+			flat:             flat,
+		}
+		if err := mc.execute(); err != nil {
+			t.Fatal(err)
+		}
+
+		expectEqualFiles(t, outdir+"/en-us.all.json", expectedDir+"/en-us.all.json")
+		expectEqualFiles(t, outdir+"/fr-fr.all.json", expectedDir+"/fr-fr.all.json")
+		expectEqualFiles(t, outdir+"/en-us.untranslated.json", expectedDir+"/en-us.untranslated.json")
+		expectEqualFiles(t, outdir+"/fr-fr.untranslated.json", expectedDir+"/fr-fr.untranslated.json")
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import "testing"
+
+func TestMergeExecuteXLIFF(t *testing.T) {
+	for _, format := range []string{"xliff12", "xliff20"} {
+		resetDir(t, "testdata/output/xliff")
+
+		mc := &mergeCommand{
+			translationFiles: []string{
+				"testdata/input/en-us.one.json",
+				"testdata/input/en-us.two.json",
+				"testdata/input/fr-fr.json",
+				"testdata/input/ar-ar.one.json",
+				"testdata/input/ar-ar.two.json",
+			},
+			sourceLanguage: "en-us",
+			outdir:         "testdata/output/xliff",
+			format:         format,
+		}
+		if err := mc.execute(); err != nil {
+			t.Fatal(err)
+		}
+
+		expectEqualFiles(t, "testdata/output/xliff/en-us.all.xlf", "testdata/expected/xliff/"+format+"/en-us.all.xlf")
+		expectEqualFiles(t, "testdata/output/xliff/ar-ar.all.xlf", "testdata/expected/xliff/"+format+"/ar-ar.all.xlf")
+		expectEqualFiles(t, "testdata/output/xliff/ar-ar.untranslated.xlf", "testdata/expected/xliff/"+format+"/ar-ar.untranslated.xlf")
+	}
+}
+
+func TestMergeExecuteXLIFFRoundTrip(t *testing.T) {
+	for _, format := range []string{"xliff12", "xliff20"} {
+		resetDir(t, "testdata/output/xliff")
+
+		mc := &mergeCommand{
+			translationFiles: []string{
+				"testdata/expected/en-us.all.json",
+				"testdata/expected/xliff/" + format + "/ar-ar.all.xlf",
+			},
+			sourceLanguage: "en-us",
+			outdir:         "testdata/output/xliff",
+			format:         "json",
+			flat:           false,
+		}
+		if err := mc.execute(); err != nil {
+			t.Fatal(err)
+		}
+
+		expectEqualFiles(t, "testdata/output/xliff/ar-ar.all.json", "testdata/expected/ar-ar.all.json")
+	}
+}
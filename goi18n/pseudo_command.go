@@ -0,0 +1,162 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/nicksnyder/go-i18n/i18n/bundle"
+	"github.com/nicksnyder/go-i18n/i18n/language"
+	"github.com/nicksnyder/go-i18n/i18n/pseudo"
+	"github.com/nicksnyder/go-i18n/i18n/translation"
+)
+
+type pseudoCommand struct {
+	translationFiles []string
+	sourceLanguage   string
+	locales          []string
+	outdir           string
+	format           string
+	flat             bool
+}
+
+func (pc *pseudoCommand) execute() error {
+	if len(pc.translationFiles) < 1 {
+		return fmt.Errorf("need at least one translation file to parse")
+	}
+
+	if lang := language.Parse(pc.sourceLanguage); lang == nil {
+		return fmt.Errorf("invalid source locale: %s", pc.sourceLanguage)
+	}
+
+	methods := make([]pseudo.Method, len(pc.locales))
+	for i, locale := range pc.locales {
+		m, ok := pseudo.MethodOf(locale)
+		if !ok {
+			return fmt.Errorf("unsupported pseudo-locale %s", locale)
+		}
+		methods[i] = m
+	}
+
+	bundle := bundle.New()
+	for _, tf := range pc.translationFiles {
+		if err := bundle.LoadTranslationFile(tf); err != nil {
+			return fmt.Errorf("failed to load translation file %s: %s\n", tf, err)
+		}
+	}
+
+	sourceLanguageTag := language.NormalizeTag(pc.sourceLanguage)
+	sourceTranslations := bundle.Translations()[sourceLanguageTag]
+	if sourceTranslations == nil {
+		return fmt.Errorf("no translations found for source locale %s", sourceLanguageTag)
+	}
+
+	mc := &mergeCommand{
+		sourceLanguage: pc.sourceLanguage,
+		outdir:         pc.outdir,
+		format:         pc.format,
+		flat:           pc.flat,
+	}
+	for _, m := range methods {
+		translations := make([]translation.Translation, 0, len(sourceTranslations))
+		for key, src := range sourceTranslations {
+			t, err := pseudo.Translation(src, m)
+			if err != nil {
+				return fmt.Errorf("failed to pseudo-localize %s: %s", key, err)
+			}
+			translations = append(translations, t)
+		}
+		localeID := language.NormalizeTag(m.Tag())
+		filename := filepath.Join(pc.outdir, fmt.Sprintf("%s.all.%s", localeID, formatExt(pc.format)))
+		if err := mc.writeTranslations(filename, pc.format, translations, localeID, sourceTranslations); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (pc *pseudoCommand) parse(arguments []string) {
+	flags := flag.NewFlagSet("pseudo", flag.ExitOnError)
+	flags.Usage = usagePseudo
+
+	sourceLanguage := flags.String("sourceLanguage", "en-us", "")
+	locales := flags.String("locales", "en-XA,ar-XB", "")
+	outdir := flags.String("outdir", ".", "")
+	format := flags.String("format", "json", "")
+	flat := flags.Bool("flat", true, "")
+
+	flags.Parse(arguments)
+
+	pc.translationFiles = flags.Args()
+	pc.sourceLanguage = *sourceLanguage
+	pc.locales = strings.Split(*locales, ",")
+	pc.outdir = *outdir
+	pc.format = *format
+	switch *format {
+	case "toml":
+		pc.flat = true
+	case "po", "xliff12", "xliff20":
+		pc.flat = false
+	default:
+		pc.flat = *flat
+	}
+}
+
+func (pc *pseudoCommand) SetArgs(args []string) {
+	pc.translationFiles = args
+}
+
+func usagePseudo() {
+	fmt.Printf(`Generate pseudo-localized translation files from the translations of the source language.
+
+Usage:
+
+    goi18n pseudo [options] [files...]
+
+Translation files:
+
+    A translation file contains the strings and translations for a single language.
+
+    Translation file names must have a suffix of a supported format (e.g. .json) and
+    contain a valid language tag as defined by RFC 5646 (e.g. en-us, fr, zh-hant, etc.).
+
+Pseudo-locales:
+
+    en-XA   Letters are accented and messages are about 40%% longer, between brackets
+            (e.g. "[Ĥéļļö {{.Person}} one two]"), which shows hard-coded strings and truncation.
+    ar-XB   Words display right-to-left, which shows the layout of right-to-left languages.
+
+    Template actions (e.g. {{.Count}}) and ICU MessageFormat arguments are not changed.
+
+Output:
+
+    goi18n writes the pseudo-localized translations of each pseudo-locale xx-yy to xx-yy.all.format.
+
+Options:
+
+    -sourceLanguage tag
+        goi18n pseudo-localizes the translations of this language.
+        Default: en-us
+
+    -locales tags
+        goi18n writes the translations of these comma separated pseudo-locales.
+        Default: en-XA,ar-XB
+
+    -outdir directory
+        goi18n writes the pseudo-localized translation files to this directory.
+        Default: .
+
+    -format format
+        goi18n encodes the pseudo-localized translation files in this format.
+        Supported formats: json, toml, yaml, po, xliff12 (XLIFF 1.2), xliff20 (XLIFF 2.0)
+        XLIFF files of both versions are written with the .xlf extension.
+        Default: json
+
+    -flat
+        goi18n writes the pseudo-localized translation files in flat format.
+        Usage of '-format toml' automitically sets this flag.
+        Default: true
+
+`)
+}
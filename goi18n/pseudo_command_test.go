@@ -0,0 +1,25 @@
+package main
+
+import "testing"
+
+func TestPseudoExecute(t *testing.T) {
+	resetDir(t, "testdata/output")
+	pc := &pseudoCommand{
+		translationFiles: []string{"testdata/input/pseudo/en-us.json"},
+		sourceLanguage:   "en-us",
+		locales:          []string{"en-XA", "ar-XB"},
+		outdir:           "testdata/output",
+		format:           "json",
+		flat:             true,
+	}
+	if err := pc.execute(); err != nil {
+		t.Fatal(err)
+	}
+	expectEqualFiles(t, "testdata/output/en-xa.all.json", "testdata/expected/pseudo/en-xa.all.json")
+	expectEqualFiles(t, "testdata/output/ar-xb.all.json", "testdata/expected/pseudo/ar-xb.all.json")
+
+	pc.locales = []string{"fr-XA"}
+	if err := pc.execute(); err == nil {
+		t.Error("expected an error for an unsupported pseudo-locale")
+	}
+}
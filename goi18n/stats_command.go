@@ -0,0 +1,186 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/nicksnyder/go-i18n/i18n/bundle"
+	"github.com/nicksnyder/go-i18n/i18n/language"
+	"github.com/nicksnyder/go-i18n/i18n/translation"
+)
+
+type statsCommand struct {
+	translationFiles []string
+	sourceLanguage   string
+	min              float64
+	out              io.Writer
+}
+
+// languageStats counts the translations of a language of the translation ids of the source language.
+type languageStats struct {
+	total      int
+	translated int
+	partial    int
+	missing    int
+}
+
+func (ls *languageStats) percent() float64 {
+	if ls.total == 0 {
+		return 100
+	}
+	return 100 * float64(ls.translated) / float64(ls.total)
+}
+
+func (sc *statsCommand) execute() error {
+	if len(sc.translationFiles) < 1 {
+		return fmt.Errorf("need at least one translation file to parse")
+	}
+
+	if lang := language.Parse(sc.sourceLanguage); lang == nil {
+		return fmt.Errorf("invalid source locale: %s", sc.sourceLanguage)
+	}
+
+	bundle := bundle.New()
+	for _, tf := range sc.translationFiles {
+		if err := bundle.LoadTranslationFile(tf); err != nil {
+			return fmt.Errorf("failed to load translation file %s: %s\n", tf, err)
+		}
+	}
+
+	translations := bundle.Translations()
+	sourceLanguageTag := language.NormalizeTag(sc.sourceLanguage)
+	sourceTranslations := translations[sourceLanguageTag]
+	if sourceTranslations == nil {
+		return fmt.Errorf("no translations found for source locale %s", sourceLanguageTag)
+	}
+
+	localeIDs := make([]string, 0, len(translations))
+	for localeID := range translations {
+		localeIDs = append(localeIDs, localeID)
+	}
+	sort.Strings(localeIDs)
+
+	out := sc.out
+	if out == nil {
+		out = os.Stdout
+	}
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "LANGUAGE\tTOTAL\tTRANSLATED\tPARTIAL\tMISSING\tCOMPLETE")
+	var belowMin []string
+	for _, localeID := range localeIDs {
+		stats := newLanguageStats(localeID, sourceTranslations, translations[localeID])
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%.1f%%\n", localeID, stats.total, stats.translated, stats.partial, stats.missing, stats.percent())
+		if stats.percent() < sc.min {
+			belowMin = append(belowMin, fmt.Sprintf("%s (%.1f%%)", localeID, stats.percent()))
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if len(belowMin) > 0 {
+		return fmt.Errorf("translations are less than %g%% complete: %v", sc.min, belowMin)
+	}
+	return nil
+}
+
+// newLanguageStats counts the translations of localeTranslations
+// with the same Incomplete logic as goi18n merge.
+func newLanguageStats(localeID string, sourceTranslations, localeTranslations map[string]translation.Translation) *languageStats {
+	lang := language.MustParse(localeID)[0]
+	stats := &languageStats{total: len(sourceTranslations)}
+	for key := range sourceTranslations {
+		t := localeTranslations[key]
+		switch {
+		case t == nil:
+			stats.missing++
+		case !t.Incomplete(lang):
+			stats.translated++
+		case partiallyTranslated(t):
+			stats.partial++
+		default:
+			stats.missing++
+		}
+	}
+	return stats
+}
+
+// partiallyTranslated reports whether an incomplete plural or select translation
+// has at least one translated template.
+func partiallyTranslated(t translation.Translation) bool {
+	if s, ok := t.(translation.Selector); ok {
+		for _, variant := range s.Variants() {
+			if partiallyTranslated(variant) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, pc := range translation.Plurals(t) {
+		if tmpl := t.Template(pc); tmpl != nil && tmpl.String() != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (sc *statsCommand) parse(arguments []string) {
+	flags := flag.NewFlagSet("stats", flag.ExitOnError)
+	flags.Usage = usageStats
+
+	sourceLanguage := flags.String("sourceLanguage", "en-us", "")
+	min := flags.Float64("min", 0, "")
+
+	flags.Parse(arguments)
+
+	sc.translationFiles = flags.Args()
+	sc.sourceLanguage = *sourceLanguage
+	sc.min = *min
+}
+
+func (sc *statsCommand) SetArgs(args []string) {
+	sc.translationFiles = args
+}
+
+func usageStats() {
+	fmt.Printf(`Report how complete the translations of each language are.
+
+Usage:
+
+    goi18n stats [options] [files...]
+
+Translation files:
+
+    A translation file contains the strings and translations for a single language.
+
+    Translation file names must have a suffix of a supported format (e.g. .json) and
+    contain a valid language tag as defined by RFC 5646 (e.g. en-us, fr, zh-hant, etc.).
+
+Output:
+
+    goi18n prints a table with a row for each language:
+
+    TOTAL       the number of translation ids of the source language.
+    TRANSLATED  the number of those ids that are fully translated.
+    PARTIAL     the number of plural translations that are missing some plural categories.
+    MISSING     the number of those ids that are not translated.
+    COMPLETE    the percentage of those ids that are fully translated.
+
+    A translation is fully translated if goi18n merge would not write it to the
+    untranslated file of the language.
+
+Options:
+
+    -sourceLanguage tag
+        Translations are counted against the translation ids of this language.
+        Default: en-us
+
+    -min percent
+        goi18n exits with status 1 if a language is less than percent complete.
+        Default: 0
+
+`)
+}
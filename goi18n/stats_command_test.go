@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+)
+
+func TestStatsExecute(t *testing.T) {
+	var out bytes.Buffer
+	sc := &statsCommand{
+		translationFiles: []string{
+			"testdata/input/stats/ar-ar.json",
+			"testdata/input/stats/en-us.json",
+			"testdata/input/stats/fr-fr.json",
+		},
+		sourceLanguage: "en-us",
+		out:            &out,
+	}
+	if err := sc.execute(); err != nil {
+		t.Fatal(err)
+	}
+	expected, err := ioutil.ReadFile("testdata/expected/stats/stats.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out.Bytes(), expected) {
+		t.Errorf("stats =\n%s\nexpected\n%s", out.Bytes(), expected)
+	}
+
+	for _, test := range []struct {
+		min  float64
+		fail bool
+	}{
+		{min: 25, fail: false},
+		{min: 50, fail: true},
+	} {
+		sc.min = test.min
+		if err := sc.execute(); (err != nil) != test.fail {
+			t.Errorf("min %g: expected failure %t but got error %v", test.min, test.fail, err)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+// DON'T CHANGE THIS FILE MANUALLY
+// This file was generated using the command:
+// $ goi18n constants
+
+package R
+
+// AdjectiveOpen is the identifier for the following localizable string template(s):
+// "Open"
+const AdjectiveOpen = "adjective\x04open"
+
+// FolderFileCount is the identifier for the following localizable string template(s):
+// one: "{{.Count}} file"
+// other: "{{.Count}} files"
+const FolderFileCount = "folder\x04file_count"
+
+// VerbOpen is the identifier for the following localizable string template(s):
+// "Open"
+const VerbOpen = "verb\x04open"
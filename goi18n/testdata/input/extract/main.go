@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nicksnyder/go-i18n/goi18n/testdata/input/extract/R"
+	"github.com/nicksnyder/go-i18n/i18n"
+	"github.com/nicksnyder/go-i18n/i18n/language"
+	"github.com/nicksnyder/go-i18n/i18n/translation"
+)
+
+const greetingPrefix = "program_"
+
+type page struct {
+	T i18n.TranslateFunc
+}
+
+func main() {
+	T := i18n.MustTfunc("en-US")
+	fmt.Println(T(greetingPrefix + "greeting"))
+	fmt.Println(T("person_greeting", translation.Default{Other: "Hi {{.Person}}"}, map[string]interface{}{"Person": "Bob"}))
+	fmt.Println(T("your_unread_email_count", translation.Default{One: "You have {{.Count}} unread email.", Other: "You have {{.Count}} unread emails."}, 2))
+	fmt.Println(T("d_days", language.Range{Start: 1, End: 3}))
+	fmt.Println(T("person_unread_email_count", map[string]interface{}{"Person": "Bob", "Count": 2}))
+	fmt.Println(T(R.MyHeightInMeters, "1.7"))
+
+	C, _ := i18n.ContextTfunc("en-US")
+	fmt.Println(C("verb", "open", translation.Default{Other: "Open"}))
+	fmt.Println(T(R.AdjectiveOpen))
+
+	p := page{T: T}
+	fmt.Println(p.T("page_title", translation.Default{Other: "Home"}))
+	fmt.Println(i18n.MustTfunc("en-US")("inline_id", translation.Default{Other: "Inline"}))
+
+	id := "dynamic_id"
+	fmt.Println(T(id))
+}
+
+func render(tr i18n.TranslateFunc, unreadCount int) string {
+	return tr("unread_badge", unreadCount)
+}
+
+func describe(T i18n.TranslateFunc, account string, discount float64, items []string) string {
+	return T("account_owner", account) + T("discount_label", discount) + T("item_total", len(items))
+}
+
+func greet(ctx context.Context, T i18n.TranslateFunc) string {
+	return i18n.T(ctx, "context_greeting", translation.Default{Other: "Hello from a context"})
+}
@@ -2,24 +2,26 @@
 package bundle
 
 import (
-	"bytes"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"path/filepath"
 	"reflect"
+	"sort"
+	"strings"
 	"sync"
-	"unicode"
 
 	"github.com/nicksnyder/go-i18n/i18n/language"
+	"github.com/nicksnyder/go-i18n/i18n/pseudo"
 	"github.com/nicksnyder/go-i18n/i18n/translation"
-	toml "github.com/pelletier/go-toml"
-	"gopkg.in/yaml.v2"
 )
 
 // TranslateFunc is a copy of i18n.TranslateFunc to avoid a circular dependency.
 type TranslateFunc func(translationID string, args ...interface{}) string
 
+// ContextTranslateFunc is a copy of i18n.ContextTranslateFunc to avoid a circular dependency.
+type ContextTranslateFunc func(context, translationID string, args ...interface{}) string
+
 // Bundle stores the translations for multiple languages.
 type Bundle struct {
 	// The primary translations for a language tag and translation id.
@@ -28,9 +30,95 @@ type Bundle struct {
 	// Translations that can be used when an exact language match is not possible.
 	fallbackTranslations map[string]map[string]translation.Translation
 
+	// pseudo pseudo-localizes translations when they are translated if pseudoEnabled is true.
+	pseudo        pseudo.Method
+	pseudoEnabled bool
+
+	// The pseudo-localized translations for a language tag and translation id.
+	// pseudoGeneration counts the changes of translations and pseudo-localization that clear them,
+	// so a pseudo-localized translation of an earlier generation is not cached.
+	pseudoTranslations map[string]map[string]translation.Translation
+	pseudoGeneration   uint64
+
+	onMissing func(lang *language.Language, translationID string, reason MissingReason)
+
+	// The translations of the default messages that were passed to translate functions.
+	defaultTranslations map[defaultKey]translation.Translation
+
+	// The language whose translations are used when no preferred language has a translation.
+	defaultLanguage *language.Language
+
+	// The tags of the languages whose translations are used when a language tag has no translation, in order.
+	fallbacks map[string][]string
+
 	sync.RWMutex
 }
 
+// MissingReason is the reason why a bundle could not translate a translation id.
+type MissingReason int
+
+const (
+	// MissingID means that there is no translation for the translation id in the language,
+	// or that the language is unknown.
+	MissingID MissingReason = iota
+
+	// MissingPluralCategory means that the translation has no template for the plural category of the count.
+	MissingPluralCategory
+
+	// TemplateFailed means that executing the template of the translation failed
+	// or returned an empty string, for example because it is not translated yet.
+	TemplateFailed
+)
+
+func (r MissingReason) String() string {
+	switch r {
+	case MissingID:
+		return "missing translation id"
+	case MissingPluralCategory:
+		return "missing plural category"
+	case TemplateFailed:
+		return "template failed"
+	}
+	return fmt.Sprintf("MissingReason(%d)", int(r))
+}
+
+// Errors of Localize.
+var (
+	// ErrMessageNotFound means that there is no translation for the translation id in the language,
+	// or that the language is unknown.
+	ErrMessageNotFound = errors.New("message not found")
+
+	// ErrPluralCategoryMissing means that the translation has no template for the plural category of the count.
+	ErrPluralCategoryMissing = errors.New("plural category missing")
+
+	// ErrEmptyMessage means that the template of the translation returned an empty string,
+	// for example because it is not translated yet.
+	ErrEmptyMessage = errors.New("empty message")
+)
+
+// TranslateError is the error of Localize.
+type TranslateError struct {
+	// Language is nil if the language is unknown.
+	Language      *language.Language
+	TranslationID string
+
+	// Plural is the plural category of the template, if the translation was found.
+	Plural language.Plural
+
+	// Err is ErrMessageNotFound, ErrPluralCategoryMissing, ErrEmptyMessage
+	// or the error of executing the template.
+	Err error
+}
+
+func (e *TranslateError) Error() string {
+	return fmt.Sprintf("failed to translate %q in %v: %s", e.TranslationID, e.Language, e.Err)
+}
+
+// Unwrap returns the cause of the error.
+func (e *TranslateError) Unwrap() error {
+	return e.Err
+}
+
 // New returns an empty bundle.
 func New() *Bundle {
 	return &Bundle{
@@ -62,148 +150,298 @@ func (b *Bundle) LoadTranslationFile(filename string) error {
 
 // ParseTranslationFileBytes is similar to LoadTranslationFile except it parses the bytes in buf.
 //
+// The format of buf is chosen by the extension of filename; see RegisterFormat.
+//
 // It is useful for parsing translation files embedded with go-bindata.
+// If a translation fails to parse, then no translation of the file is added.
 func (b *Bundle) ParseTranslationFileBytes(filename string, buf []byte) error {
+	lang, translations, err := ParseTranslationFile(filename, buf)
+	if err != nil {
+		return err
+	}
+	b.AddTranslation(lang, translations...)
+	return nil
+}
+
+// ParseTranslationFile returns the language of filename and the translations in buf
+// without adding them to a bundle.
+//
+// Unlike ParseTranslationFileBytes, it keeps going when translations fail to parse:
+// it returns the translations that parse and a TranslationErrors that lists the translations that failed.
+// The language is nil if the error is not a TranslationErrors.
+func ParseTranslationFile(filename string, buf []byte) (*language.Language, []translation.Translation, error) {
 	basename := filepath.Base(filename)
 	langs := language.Parse(basename)
 	switch l := len(langs); {
 	case l == 0:
-		return fmt.Errorf("no language found in %q", basename)
+		return nil, nil, fmt.Errorf("no language found in %q", basename)
 	case l > 1:
-		return fmt.Errorf("multiple languages found in filename %q: %v; expected one", basename, langs)
+		return nil, nil, fmt.Errorf("multiple languages found in filename %q: %v; expected one", basename, langs)
 	}
-	translations, err := parseTranslations(filename, buf)
-	if err != nil {
-		return err
+	translations, err := parseTranslations(langs[0], filename, buf)
+	if _, ok := err.(TranslationErrors); err != nil && !ok {
+		return nil, nil, err
 	}
-	b.AddTranslation(langs[0], translations...)
-	return nil
+	return langs[0], translations, err
 }
 
-func parseTranslations(filename string, buf []byte) ([]translation.Translation, error) {
-	if len(buf) == 0 {
-		return []translation.Translation{}, nil
-	}
-
-	ext := filepath.Ext(filename)
-
-	// `github.com/pelletier/go-toml` lacks an Unmarshal function,
-	// so we should parse TOML separately.
-	if ext == ".toml" {
-		tree, err := toml.LoadReader(bytes.NewReader(buf))
-		if err != nil {
-			return nil, err
-		}
+// FileError records a translation file that could not be loaded.
+type FileError struct {
+	Filename string
+	Err      error
+}
 
-		m := make(map[string]map[string]interface{})
-		for k, v := range tree.ToMap() {
-			m[k] = v.(map[string]interface{})
-		}
+func (fe *FileError) Error() string {
+	return fmt.Sprintf("%s: %s", fe.Filename, fe.Err)
+}
 
-		return parseFlatFormat(m)
-	}
+// FileErrors is returned when one or more of several translation files could not be loaded.
+type FileErrors []*FileError
 
-	// Then parse other formats.
-	if isStandardFormat(ext, buf) {
-		var standardFormat []map[string]interface{}
-		if err := unmarshal(ext, buf, &standardFormat); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal %v: %v", filename, err)
-		}
-		return parseStandardFormat(standardFormat)
-	} else {
-		var flatFormat map[string]map[string]interface{}
-		if err := unmarshal(ext, buf, &flatFormat); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal %v: %v", filename, err)
-		}
-		return parseFlatFormat(flatFormat)
+func (fe FileErrors) Error() string {
+	msgs := make([]string, len(fe))
+	for i, err := range fe {
+		msgs[i] = err.Error()
 	}
+	return fmt.Sprintf("failed to load %d translation file(s):\n%s", len(fe), strings.Join(msgs, "\n"))
 }
 
-func isStandardFormat(ext string, buf []byte) bool {
-	buf = deleteLeadingComments(ext, buf)
-	firstRune := rune(buf[0])
-	return (ext == ".json" && firstRune == '[') || (ext == ".yaml" && firstRune == '-')
-}
+func parseTranslations(lang *language.Language, filename string, buf []byte) ([]translation.Translation, error) {
+	if len(buf) == 0 {
+		return []translation.Translation{}, nil
+	}
 
-// deleteLeadingComments deletes leading newlines and comments in buf.
-// It only works for ext == ".yaml".
-func deleteLeadingComments(ext string, buf []byte) []byte {
-	if ext != ".yaml" {
-		return buf
+	data, err := unmarshal(filepath.Ext(filename), buf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %v: %v", filename, err)
 	}
+	syntax := fileSyntax(filename)
 
-	for {
-		buf = bytes.TrimLeftFunc(buf, unicode.IsSpace)
-		if buf[0] == '#' {
-			buf = deleteLine(buf)
-		} else {
-			break
+	switch data := data.(type) {
+	case nil:
+		// The file only contains comments.
+		return []translation.Translation{}, nil
+	case []interface{}:
+		standardFormat := make([]map[string]interface{}, len(data))
+		for i, d := range data {
+			m, ok := stringMap(d)
+			if !ok {
+				return nil, fmt.Errorf("unable to parse translation #%d in %v: expected an object but got %T", i, filename, d)
+			}
+			standardFormat[i] = m
 		}
+		return parseStandardFormat(lang, syntax, standardFormat)
+	default:
+		m, ok := stringMap(data)
+		if !ok {
+			return nil, fmt.Errorf("unable to parse %v: expected a list or an object but got %T", filename, data)
+		}
+		flatFormat := make(map[string]map[string]interface{}, len(m))
+		for id, d := range m {
+			translationData, ok := stringMap(d)
+			if !ok {
+				return nil, fmt.Errorf("unable to parse translation %q in %v: expected an object but got %T", id, filename, d)
+			}
+			flatFormat[id] = translationData
+		}
+		return parseFlatFormat(lang, syntax, flatFormat)
 	}
-
-	return buf
 }
 
-func deleteLine(buf []byte) []byte {
-	index := bytes.IndexRune(buf, '\n')
-	if index == -1 { // If there is only one line without newline ...
-		return nil // ... delete it and return nothing.
-	}
-	if index == len(buf)-1 { // If there is only one line with newline ...
-		return nil // ... do the same as above.
+// fileSyntax returns the syntax of the translations in filename
+// that do not have a "syntax" key, or "" for the default syntax.
+//
+// A file of ICU MessageFormat translations has an "icu" segment in its name (e.g. en-US.icu.json).
+func fileSyntax(filename string) string {
+	segments := strings.Split(filepath.Base(filename), ".")
+	for _, segment := range segments[:len(segments)-1] {
+		if strings.EqualFold(segment, translation.ICUSyntax) {
+			return translation.ICUSyntax
+		}
 	}
-	return buf[index+1:]
+	return ""
 }
 
-// unmarshal finds an appropriate unmarshal function for ext
-// (extension of filename) and unmarshals buf to out. out must be a pointer.
-func unmarshal(ext string, buf []byte, out interface{}) error {
-	switch ext {
-	case ".json":
-		return json.Unmarshal(buf, out)
-	case ".yaml":
-		return yaml.Unmarshal(buf, out)
+// stringMap converts the objects produced by decoders to map[string]interface{}.
+// The YAML decoder uses interface{} keys, so they are converted to strings.
+func stringMap(v interface{}) (map[string]interface{}, bool) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		return v, true
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			m[fmt.Sprint(k)] = val
+		}
+		return m, true
 	}
-
-	return fmt.Errorf("unsupported file extension %v", ext)
+	return nil, false
 }
 
-func parseStandardFormat(data []map[string]interface{}) ([]translation.Translation, error) {
+// parseStandardFormat creates translations from data in the standard format.
+//
+// The plural forms of a translation may also be given as a list,
+// in which case they are mapped onto the plural categories of lang in CLDR order
+// (e.g. ["one form", "other form"] for English).
+// This is how the msgstr[n] forms of gettext PO files are represented.
+// A list may come with "nplurals", the number of plural forms that the file declares
+// (e.g. the Plural-Forms header of a PO file), which must be the number of plural categories of lang.
+//
+// If syntax is not empty, it is the syntax of the translations that do not have a "syntax" key.
+// parseStandardFormat returns the translations of data that parse,
+// and a TranslationErrors if some translations fail to parse.
+func parseStandardFormat(lang *language.Language, syntax string, data []map[string]interface{}) ([]translation.Translation, error) {
 	translations := make([]translation.Translation, 0, len(data))
-	for i, translationData := range data {
+	var errs TranslationErrors
+	for _, translationData := range data {
+		if _, ok := translationData["syntax"]; !ok && syntax != "" {
+			translationData["syntax"] = syntax
+		}
+		nplurals, _ := translationData["nplurals"].(int)
+		delete(translationData, "nplurals")
+		if forms, ok := translationData["translation"].([]interface{}); ok {
+			plurals, err := pluralForms(lang, forms, nplurals)
+			if err != nil {
+				errs = append(errs, newTranslationError(translationData, err))
+				continue
+			}
+			translationData["translation"] = plurals
+		}
 		t, err := translation.NewTranslation(translationData)
 		if err != nil {
-			return nil, fmt.Errorf("unable to parse translation #%d because %s\n%v", i, err, translationData)
+			errs = append(errs, newTranslationError(translationData, err))
+			continue
 		}
 		translations = append(translations, t)
 	}
+	if len(errs) > 0 {
+		sort.Sort(errs)
+		return translations, errs
+	}
 	return translations, nil
 }
 
+// TranslationError records a translation of a translation file that could not be parsed.
+type TranslationError struct {
+	ID      string
+	Context string
+	Err     error
+}
+
+func newTranslationError(data map[string]interface{}, err error) *TranslationError {
+	id, _ := data["id"].(string)
+	context, _ := data["context"].(string)
+	return &TranslationError{id, context, err}
+}
+
+func (te *TranslationError) Error() string {
+	if te.Context != "" {
+		return fmt.Sprintf("unable to parse translation %q in context %q because %s", te.ID, te.Context, te.Err)
+	}
+	return fmt.Sprintf("unable to parse translation %q because %s", te.ID, te.Err)
+}
+
+// TranslationErrors lists the translations of a translation file that could not be parsed, sorted by id.
+type TranslationErrors []*TranslationError
+
+func (te TranslationErrors) Error() string {
+	msgs := make([]string, len(te))
+	for i, err := range te {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+func (te TranslationErrors) Len() int      { return len(te) }
+func (te TranslationErrors) Swap(i, j int) { te[i], te[j] = te[j], te[i] }
+func (te TranslationErrors) Less(i, j int) bool {
+	return te[i].ID < te[j].ID || te[i].ID == te[j].ID && te[i].Context < te[j].Context
+}
+
 // parseFlatFormat just converts data from flat format to standard format
 // and passes it to parseStandardFormat.
 //
 // Flat format logic:
 // key of data must be a string and data[key] must be always map[string]interface{},
 // but if there is only "other" key in it then it is non-plural, else plural.
-func parseFlatFormat(data map[string]map[string]interface{}) ([]translation.Translation, error) {
+// Keys that are not plural categories, such as "syntax", are copied to the standard format.
+// The key of a translation with a context is the key returned by translation.Key.
+// A "context" key is only needed if the key has no context (e.g. the key "open" with the "context" "verb");
+// a translation whose "context" key is not the context of its key is reported in the returned TranslationErrors.
+// Ordinal translations are always plural.
+// The other keys of a select translation are its variants.
+func parseFlatFormat(lang *language.Language, syntax string, data map[string]map[string]interface{}) ([]translation.Translation, error) {
 	var standardFormatData []map[string]interface{}
+	var errs TranslationErrors
 	for id, translationData := range data {
 		dataObject := make(map[string]interface{})
-		dataObject["id"] = id
-		if len(translationData) == 1 { // non-plural form
-			_, otherExists := translationData["other"]
+		context, translationID := translation.SplitKey(id)
+		dataObject["id"] = translationID
+		if context != "" {
+			dataObject["context"] = context
+		}
+		forms := make(map[string]interface{}, len(translationData))
+		for k, v := range translationData {
+			if flatMetadataKeys[k] {
+				dataObject[k] = v
+			} else {
+				forms[k] = v
+			}
+		}
+		// A "context" key gives the context of a key without one; otherwise it may only repeat it.
+		if c, ok := translationData["context"]; ok && context != "" && c != context {
+			errs = append(errs, &TranslationError{translationID, context, fmt.Errorf(`"context" key %q does not match the context %q of the key`, c, context)})
+			continue
+		}
+		_, selectForm := dataObject["select"]
+		if len(forms) == 1 && dataObject["ordinal"] != true && !selectForm { // non-plural form
+			_, otherExists := forms["other"]
 			if otherExists {
-				dataObject["translation"] = translationData["other"]
+				dataObject["translation"] = forms["other"]
 			}
-		} else { // plural form
-			dataObject["translation"] = translationData
+		} else { // plural or select form
+			dataObject["translation"] = forms
 		}
 
 		standardFormatData = append(standardFormatData, dataObject)
 	}
 
-	return parseStandardFormat(standardFormatData)
+	translations, err := parseStandardFormat(lang, syntax, standardFormatData)
+	if len(errs) == 0 {
+		return translations, err
+	}
+	if standardErrs, ok := err.(TranslationErrors); ok {
+		errs = append(errs, standardErrs...)
+	}
+	sort.Sort(errs)
+	return translations, errs
+}
+
+// flatMetadataKeys are the keys of a translation in the flat format that are not plural categories.
+var flatMetadataKeys = map[string]bool{
+	"syntax":  true,
+	"ordinal": true,
+	"select":  true,
+
+	"description": true,
+	"context":     true,
+	"maxLength":   true,
+	"html":        true,
+}
+
+func pluralForms(lang *language.Language, forms []interface{}, nplurals int) (map[string]interface{}, error) {
+	plurals := lang.SortedPlurals()
+	if nplurals > 0 && nplurals != len(plurals) {
+		return nil, fmt.Errorf("the file has %d plural forms but %s has %d plural categories", nplurals, lang, len(plurals))
+	}
+	if len(forms) > len(plurals) {
+		return nil, fmt.Errorf("%d plural forms given but %s only has %d plural categories", len(forms), lang, len(plurals))
+	}
+	m := make(map[string]interface{}, len(forms))
+	for i, form := range forms {
+		m[string(plurals[i])] = form
+	}
+	return m, nil
 }
 
 // AddTranslation adds translations for a language.
@@ -217,20 +455,167 @@ func (b *Bundle) AddTranslation(lang *language.Language, translations ...transla
 	}
 	currentTranslations := b.translations[lang.Tag]
 	for _, newTranslation := range translations {
-		if currentTranslation := currentTranslations[newTranslation.ID()]; currentTranslation != nil {
-			currentTranslations[newTranslation.ID()] = currentTranslation.Merge(newTranslation)
+		key := translation.KeyOf(newTranslation)
+		if currentTranslation := currentTranslations[key]; currentTranslation != nil {
+			// Merge changes its receiver, which translate functions and the caller of SetTranslations may hold.
+			currentTranslations[key] = translation.Copy(currentTranslation).Merge(newTranslation)
 		} else {
-			currentTranslations[newTranslation.ID()] = newTranslation
+			currentTranslations[key] = newTranslation
 		}
 	}
 
-	// lang can provide translations for less specific language tags.
+	// lang can provide translations for less specific language tags (see language.Tag.Parent).
 	for _, tag := range lang.MatchingTags() {
 		b.fallbackTranslations[tag] = currentTranslations
 	}
+	b.clearPseudoTranslations()
+}
+
+// SetTranslations replaces all translations of a language with translations,
+// so translations that are not in translations are removed.
+// The language is removed if translations is empty.
+//
+// Translate functions see either all of the old translations of the language or all of the new ones.
+func (b *Bundle) SetTranslations(lang *language.Language, translations ...translation.Translation) {
+	newTranslations := make(map[string]translation.Translation, len(translations))
+	for _, newTranslation := range translations {
+		key := translation.KeyOf(newTranslation)
+		if currentTranslation := newTranslations[key]; currentTranslation != nil {
+			// Merge changes its receiver, which belongs to the caller (e.g. the files of a Watcher).
+			newTranslations[key] = translation.Copy(currentTranslation).Merge(newTranslation)
+		} else {
+			newTranslations[key] = newTranslation
+		}
+	}
+	b.Lock()
+	defer b.Unlock()
+	oldTranslations := b.translations[lang.Tag]
+	if len(newTranslations) == 0 {
+		newTranslations = nil
+		delete(b.translations, lang.Tag)
+	} else {
+		b.translations[lang.Tag] = newTranslations
+	}
+
+	// Less specific language tags that other languages provide translations for keep them.
+	for _, tag := range lang.MatchingTags() {
+		if current := b.fallbackTranslations[tag]; current != nil && !sameMap(current, oldTranslations) {
+			continue
+		}
+		if newTranslations != nil {
+			b.fallbackTranslations[tag] = newTranslations
+		} else if other := b.matchingTranslations(tag); other != nil {
+			b.fallbackTranslations[tag] = other
+		} else {
+			delete(b.fallbackTranslations, tag)
+		}
+	}
+	b.clearPseudoTranslations()
+}
+
+// matchingTranslations returns the translations of a language that can provide translations for tag,
+// or nil if there is none. The caller must hold the lock of b.
+func (b *Bundle) matchingTranslations(tag string) map[string]translation.Translation {
+	var tags []string
+	for t := range b.translations {
+		tags = append(tags, t)
+	}
+	sort.Strings(tags)
+	for _, t := range tags {
+		for _, lang := range language.Parse(t) {
+			for _, matchingTag := range lang.MatchingTags() {
+				if matchingTag == tag {
+					return b.translations[t]
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// sameMap reports whether a and b are the same map.
+func sameMap(a, b map[string]translation.Translation) bool {
+	return a != nil && b != nil && reflect.ValueOf(a).Pointer() == reflect.ValueOf(b).Pointer()
+}
+
+// SetPseudo makes the bundle pseudo-localize every translation with m when it translates it,
+// so hard-coded strings and truncated messages stand out before the real translations are available
+// (see package pseudo).
+func (b *Bundle) SetPseudo(m pseudo.Method) {
+	b.Lock()
+	defer b.Unlock()
+	b.pseudo = m
+	b.pseudoEnabled = true
+	b.clearPseudoTranslations()
+}
+
+// ClearPseudo stops the pseudo-localization started by SetPseudo.
+func (b *Bundle) ClearPseudo() {
+	b.Lock()
+	defer b.Unlock()
+	b.pseudoEnabled = false
+	b.clearPseudoTranslations()
+}
+
+// clearPseudoTranslations clears the pseudo-localized translations. The caller must hold the lock of b.
+func (b *Bundle) clearPseudoTranslations() {
+	b.pseudoTranslations = nil
+	b.pseudoGeneration++
+}
+
+// SetDefaultLanguage sets the language whose translations are used when none of the preferred languages
+// of a translate function has a translation for a translation id (e.g. the source language of the translations).
+//
+// If none of the preferred languages of Tfunc has translations, the translate function is bound to the default language.
+func (b *Bundle) SetDefaultLanguage(tag string) error {
+	lang, err := parseTag(tag)
+	if err != nil {
+		return err
+	}
+	b.Lock()
+	defer b.Unlock()
+	b.defaultLanguage = lang
+	return nil
 }
 
-// Translations returns all translations in the bundle.
+// AddFallback makes the bundle use the translations of fallbackTags, in order,
+// when the language tag has no translation for a translation id (e.g. AddFallback("pt-BR", "pt-PT")).
+//
+// A translate function looks up a translation id in each preferred language, then in its fallbacks,
+// then in its parent languages (see language.Tag.Parent, e.g. "fr" for "fr-CA" and "zh-Hant" for "zh-TW")
+// and their fallbacks,
+// and finally in the default language (see SetDefaultLanguage).
+func (b *Bundle) AddFallback(tag string, fallbackTags ...string) error {
+	lang, err := parseTag(tag)
+	if err != nil {
+		return err
+	}
+	fallbacks := make([]string, len(fallbackTags))
+	for i, fallbackTag := range fallbackTags {
+		fallback, err := parseTag(fallbackTag)
+		if err != nil {
+			return err
+		}
+		fallbacks[i] = fallback.Tag
+	}
+	b.Lock()
+	defer b.Unlock()
+	if b.fallbacks == nil {
+		b.fallbacks = make(map[string][]string)
+	}
+	b.fallbacks[lang.Tag] = append(b.fallbacks[lang.Tag], fallbacks...)
+	return nil
+}
+
+func parseTag(tag string) (*language.Language, error) {
+	langs := language.Parse(tag)
+	if len(langs) != 1 {
+		return nil, fmt.Errorf("invalid language tag %q", tag)
+	}
+	return langs[0], nil
+}
+
+// Translations returns all translations in the bundle by language tag and translation key (see translation.Key).
 func (b *Bundle) Translations() map[string]map[string]translation.Translation {
 	t := make(map[string]map[string]translation.Translation)
 	b.RLock()
@@ -256,6 +641,7 @@ func (b *Bundle) LanguageTags() []string {
 }
 
 // LanguageTranslationIDs returns the ids of all translations that have been added for a given language.
+// The ids of translations with a context are translation keys (see translation.Key).
 func (b *Bundle) LanguageTranslationIDs(languageTag string) []string {
 	var ids []string
 	b.RLock()
@@ -291,7 +677,13 @@ func (b *Bundle) Tfunc(pref string, prefs ...string) (TranslateFunc, error) {
 }
 
 // TfuncAndLanguage returns a TranslateFunc for the first Language that
-// has a non-zero number of translations in the bundle.
+// has a non-zero number of translations in the bundle, either itself or through its fallbacks (see AddFallback)
+// and its parent languages.
+// If no Language has translations, the TranslateFunc is bound to the default language, if any (see SetDefaultLanguage).
+//
+// A translation id that the Language has no translation for is looked up in the fallbacks and parent languages
+// of the Language, then in the languages of the preferences that follow it, and finally in the default language,
+// as they are configured when TfuncAndLanguage is called.
 //
 // The returned Language matches the the first language preference that could be satisfied,
 // but this may not strictly match the language of the translations used to satisfy that preference.
@@ -299,61 +691,264 @@ func (b *Bundle) Tfunc(pref string, prefs ...string) (TranslateFunc, error) {
 // For example, the user may request "zh". If there are no translations for "zh" but there are translations
 // for "zh-cn", then the translations for "zh-cn" will be used but the returned Language will be "zh".
 //
-// It can parse languages from Accept-Language headers (RFC 2616),
-// but it assumes weights are monotonically decreasing.
+// Each preference is a language tag or an Accept-Language header, whose languages are ordered by their quality values
+// (see language.ParseAcceptLanguage). Languages with a quality value of 0 are not used,
+// and wildcards are satisfied by the default language.
 func (b *Bundle) TfuncAndLanguage(pref string, prefs ...string) (TranslateFunc, *language.Language, error) {
-	lang := b.supportedLanguage(pref, prefs...)
+	lang, langs := b.preferredLanguages(pref, prefs...)
 	var err error
 	if lang == nil {
 		err = fmt.Errorf("no supported languages found %#v", append(prefs, pref))
 	}
 	return func(translationID string, args ...interface{}) string {
-		return b.translate(lang, translationID, args...)
+		return b.translate(lang, langs, translationID, args...)
 	}, lang, err
 }
 
-// supportedLanguage returns the first language which
-// has a non-zero number of translations in the bundle.
-func (b *Bundle) supportedLanguage(pref string, prefs ...string) *language.Language {
-	lang := b.translatedLanguage(pref)
-	if lang == nil {
-		for _, pref := range prefs {
-			lang = b.translatedLanguage(pref)
-			if lang != nil {
-				break
+// preferredLanguages returns the language of TfuncAndLanguage for the preferences
+// and the languages whose translations are looked up for it, in order.
+func (b *Bundle) preferredLanguages(pref string, prefs ...string) (*language.Language, []*language.Language) {
+	langs := b.supportedLanguages(pref, prefs...)
+	var lang *language.Language
+	if len(langs) > 0 {
+		lang = langs[0]
+	} else {
+		b.RLock()
+		lang = b.defaultLanguage
+		b.RUnlock()
+	}
+	return lang, b.fallbackLanguages(langs)
+}
+
+// MustContextTfunc is similar to ContextTfunc except it panics if an error happens.
+func (b *Bundle) MustContextTfunc(pref string, prefs ...string) ContextTranslateFunc {
+	tfunc, err := b.ContextTfunc(pref, prefs...)
+	if err != nil {
+		panic(err)
+	}
+	return tfunc
+}
+
+// ContextTfunc is similar to Tfunc except the returned function translates
+// the translation with translationID in a context (e.g. "verb").
+func (b *Bundle) ContextTfunc(pref string, prefs ...string) (ContextTranslateFunc, error) {
+	tfunc, err := b.Tfunc(pref, prefs...)
+	return func(context, translationID string, args ...interface{}) string {
+		key := translation.Key(context, translationID)
+		if s := tfunc(key, args...); s != key {
+			return s
+		}
+		return translationID
+	}, err
+}
+
+// supportedLanguages returns the languages of the preferences, starting with the first language which
+// has a non-zero number of translations in the bundle, or nil if there is none.
+func (b *Bundle) supportedLanguages(pref string, prefs ...string) []*language.Language {
+	var langs []*language.Language
+	for _, src := range append([]string{pref}, prefs...) {
+		langs = append(langs, language.Languages(language.ParseAcceptLanguage(src))...)
+	}
+	b.RLock()
+	defer b.RUnlock()
+	for i, lang := range langs {
+		for _, l := range b.languageChain([]*language.Language{lang}) {
+			if len(b.languageTranslations(l.Tag)) > 0 {
+				return langs[i:]
 			}
 		}
 	}
-	return lang
+	return nil
 }
 
-func (b *Bundle) translatedLanguage(src string) *language.Language {
-	langs := language.Parse(src)
+// fallbackLanguages returns the languages whose translations are looked up, in order, for the preferred languages langs:
+// each language followed by its fallbacks and its parent languages, and then the default language.
+func (b *Bundle) fallbackLanguages(langs []*language.Language) []*language.Language {
 	b.RLock()
 	defer b.RUnlock()
-	for _, lang := range langs {
-		if len(b.translations[lang.Tag]) > 0 ||
-			len(b.fallbackTranslations[lang.Tag]) > 0 {
-			return lang
+	if b.defaultLanguage != nil {
+		langs = append(langs[:len(langs):len(langs)], b.defaultLanguage)
+	}
+	return b.languageChain(langs)
+}
+
+// languageChain returns each of langs followed by its fallbacks and its parent languages, without duplicates.
+// The caller must hold the lock of b.
+func (b *Bundle) languageChain(langs []*language.Language) []*language.Language {
+	var chain []*language.Language
+	visited := make(map[string]bool)
+	var add func(lang *language.Language)
+	add = func(lang *language.Language) {
+		if visited[lang.Tag] {
+			return
+		}
+		visited[lang.Tag] = true
+		chain = append(chain, lang)
+		for _, fallback := range b.fallbacks[lang.Tag] {
+			add(language.MustParse(fallback)[0])
+		}
+		tag, err := lang.ParsedTag()
+		if err != nil {
+			return
+		}
+		for tag = tag.Parent(); !tag.IsRoot(); tag = tag.Parent() {
+			if parents := language.Parse(tag.String()); len(parents) == 1 {
+				add(parents[0])
+			}
 		}
 	}
-	return nil
+	for _, lang := range langs {
+		add(lang)
+	}
+	return chain
 }
 
-func (b *Bundle) translate(lang *language.Language, translationID string, args ...interface{}) string {
-	if lang == nil {
-		return translationID
+// OnMissing registers f to be called whenever a translate function of the bundle cannot translate
+// a translation id, with the reason why. lang is nil if the language is unknown.
+// The translation id of a ContextTranslateFunc is passed as translation.Key(context, translationID).
+// f can log or count missing translations, or panic in tests.
+// OnMissing(nil) removes f.
+func (b *Bundle) OnMissing(f func(lang *language.Language, translationID string, reason MissingReason)) {
+	b.Lock()
+	defer b.Unlock()
+	b.onMissing = f
+}
+
+// missing calls the function registered with OnMissing and returns translationID.
+func (b *Bundle) missing(lang *language.Language, translationID string, reason MissingReason) string {
+	b.RLock()
+	f := b.onMissing
+	b.RUnlock()
+	if f != nil {
+		f(lang, translationID, reason)
 	}
+	return translationID
+}
 
-	translation := b.translation(lang, translationID)
-	if translation == nil {
-		return translationID
+// Translate returns the translation of translationID in lang with args, like a TranslateFunc of lang.
+// Like Localize, it falls back to the fallbacks and parent languages of lang and to the default language,
+// and lang can be nil.
+func (b *Bundle) Translate(lang *language.Language, translationID string, args ...interface{}) string {
+	var langs []*language.Language
+	if lang != nil {
+		langs = append(langs, lang)
+	}
+	return b.translate(lang, b.fallbackLanguages(langs), translationID, args...)
+}
+
+func (b *Bundle) translate(lang *language.Language, langs []*language.Language, translationID string, args ...interface{}) string {
+	s, err := b.localize(lang, langs, translationID, args...)
+	if err == nil {
+		return s
+	}
+	return b.failed(lang, translationID, err)
+}
+
+// failed calls the function registered with OnMissing for the error of localize
+// and returns what a translate function returns instead of the translation.
+func (b *Bundle) failed(lang *language.Language, translationID string, err error) string {
+	switch err := err.(*TranslateError).Err; err {
+	case ErrMessageNotFound:
+		return b.missing(lang, translationID, MissingID)
+	case ErrPluralCategoryMissing:
+		return b.missing(lang, translationID, MissingPluralCategory)
+	case ErrEmptyMessage:
+		return b.missing(lang, translationID, TemplateFailed)
+	default:
+		// The error is shown instead of the translation.
+		b.missing(lang, translationID, TemplateFailed)
+		return err.Error()
+	}
+}
+
+// Localize returns the translation of translationID in lang with args, like a TranslateFunc,
+// except it returns an error instead of falling back to translationID.
+// Like a TranslateFunc, it falls back to the fallbacks and parent languages of lang and to the default language.
+//
+// If the first argument is a translation.Default, its messages are used
+// when there is no translation for translationID in any of those languages, or when lang is nil.
+//
+// The error is a *TranslateError whose Err is ErrMessageNotFound, ErrPluralCategoryMissing,
+// ErrEmptyMessage or the error of executing the template.
+func (b *Bundle) Localize(lang *language.Language, translationID string, args ...interface{}) (string, error) {
+	var langs []*language.Language
+	if lang != nil {
+		langs = append(langs, lang)
+	}
+	return b.localize(lang, b.fallbackLanguages(langs), translationID, args...)
+}
+
+// localize returns the translation of translationID in the first of langs that can translate it.
+// Errors that are not caused by a missing translation are returned without trying the next languages.
+func (b *Bundle) localize(lang *language.Language, langs []*language.Language, translationID string, args ...interface{}) (string, error) {
+	s, _, err := b.localizeHTML(lang, langs, false, translationID, args...)
+	return s, err
+}
+
+// localizeHTML is like localize, except if html is true and the translation is marked as HTML (see translation.Metadata),
+// the string values of its template data are escaped for HTML and it returns true.
+func (b *Bundle) localizeHTML(lang *language.Language, langs []*language.Language, html bool, translationID string, args ...interface{}) (string, bool, error) {
+	var defaultMessage *translation.Default
+	if len(args) > 0 {
+		if d, ok := args[0].(translation.Default); ok {
+			defaultMessage = &d
+			args = args[1:]
+		}
 	}
+	a := parseArgs(args)
 
+	var firstErr error
+	for _, l := range langs {
+		t := b.translation(l, translationID)
+		if t == nil {
+			continue
+		}
+		isHTML := html && t.Metadata().HTML
+		s, err := b.render(l, translationID, b.pseudoTranslation(l, translationID, t), false, isHTML, a)
+		if err == nil {
+			return s, isHTML, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+		switch err.(*TranslateError).Err {
+		case ErrMessageNotFound, ErrPluralCategoryMissing, ErrEmptyMessage:
+		default:
+			return "", false, err
+		}
+	}
+	if defaultMessage != nil {
+		t, err := b.defaultTranslation(translationID, *defaultMessage)
+		if err != nil {
+			return "", false, &TranslateError{Language: lang, TranslationID: translationID, Err: err}
+		}
+		s, err := b.render(lang, translationID, t, true, false, a)
+		return s, false, err
+	}
+	if firstErr != nil {
+		return "", false, firstErr
+	}
+	return "", false, &TranslateError{Language: lang, TranslationID: translationID, Err: ErrMessageNotFound}
+}
+
+// arguments are the arguments of a translate function after the translation id and the default message.
+type arguments struct {
+	data        interface{}
+	count       interface{}
+	numberRange *language.Range
+}
+
+func parseArgs(args []interface{}) arguments {
 	var data interface{}
 	var count interface{}
+	var numberRange *language.Range
 	if argc := len(args); argc > 0 {
-		if isNumber(args[0]) {
+		if r, ok := args[0].(language.Range); ok {
+			numberRange = &r
+			if argc > 1 {
+				data = args[1]
+			}
+		} else if isNumber(args[0]) {
 			count = args[0]
 			if argc > 1 {
 				data = args[1]
@@ -363,7 +958,15 @@ func (b *Bundle) translate(lang *language.Language, translationID string, args .
 		}
 	}
 
-	if count != nil {
+	if numberRange != nil {
+		dataMap := toMap(data)
+		if dataMap == nil {
+			dataMap = make(map[string]interface{})
+		}
+		dataMap["Start"] = numberRange.Start
+		dataMap["End"] = numberRange.End
+		data = dataMap
+	} else if count != nil {
 		if data == nil {
 			data = map[string]interface{}{"Count": count}
 		} else {
@@ -377,31 +980,148 @@ func (b *Bundle) translate(lang *language.Language, translationID string, args .
 			count = c
 		}
 	}
+	return arguments{data: data, count: count, numberRange: numberRange}
+}
+
+// render executes the template of translation for the plural category of a in lang.
+// usingDefault is true if translation is a default message.
+// If html is true, the template is HTML and the template data is escaped.
+func (b *Bundle) render(lang *language.Language, translationID string, translation translation.Translation, usingDefault, html bool, a arguments) (string, error) {
+	translation = selectVariant(translation, a.data)
+	if translation == nil {
+		return "", &TranslateError{Language: lang, TranslationID: translationID, Err: ErrMessageNotFound}
+	}
 
-	p, _ := lang.Plural(count)
+	var p language.Plural
+	switch {
+	case lang == nil:
+		// Only a default message can be translated without a language, with its Other message.
+		p = language.Other
+	case a.numberRange != nil:
+		p, _ = lang.RangePlural(*a.numberRange)
+	case translation.Ordinal():
+		p, _ = lang.Ordinal(a.count)
+	default:
+		p, _ = lang.Plural(a.count)
+	}
 	template := translation.Template(p)
+	if template == nil && usingDefault {
+		// The default message is written in the source language, which can have other plural categories than lang.
+		template = translation.Template(language.Other)
+	}
 	if template == nil {
-		return translationID
+		return "", &TranslateError{Language: lang, TranslationID: translationID, Plural: p, Err: ErrPluralCategoryMissing}
 	}
 
-	s := template.Execute(data)
+	render := template.Render
+	if html {
+		render = template.RenderHTML
+	}
+	s, err := render(lang, a.data)
+	if err != nil {
+		return "", &TranslateError{Language: lang, TranslationID: translationID, Plural: p, Err: err}
+	}
 	if s == "" {
-		return translationID
+		return "", &TranslateError{Language: lang, TranslationID: translationID, Plural: p, Err: ErrEmptyMessage}
+	}
+	return s, nil
+}
+
+// selectVariant returns the variant of t that matches data if t is a select translation.
+func selectVariant(t translation.Translation, data interface{}) translation.Translation {
+	if s, ok := t.(translation.Selector); ok {
+		return s.Select(toMap(data))
 	}
-	return s
+	return t
 }
 
 func (b *Bundle) translation(lang *language.Language, translationID string) translation.Translation {
 	b.RLock()
 	defer b.RUnlock()
-	translations := b.translations[lang.Tag]
-	if translations == nil {
-		translations = b.fallbackTranslations[lang.Tag]
-		if translations == nil {
-			return nil
+	return b.languageTranslations(lang.Tag)[translationID]
+}
+
+// languageTranslations returns the translations of the language tag,
+// or else the translations of a more specific language that can be used for it.
+// The caller must hold the lock of b.
+func (b *Bundle) languageTranslations(tag string) map[string]translation.Translation {
+	if translations := b.translations[tag]; translations != nil {
+		return translations
+	}
+	if translations := b.fallbackTranslations[tag]; translations != nil {
+		return translations
+	}
+	// A language with different subtags can have the same likely subtags (e.g. "zh-TW" and "zh-Hant").
+	return b.fallbackTranslations[maximizedTag(tag)]
+}
+
+// maximizedTag returns the normalized tag with likely subtags of the language tag (see language.Tag.Maximize).
+func maximizedTag(tag string) string {
+	t, err := language.ParseTag(tag)
+	if err != nil {
+		return tag
+	}
+	return language.NormalizeTag(t.Maximize().String())
+}
+
+// defaultTranslation returns the translation with translationID whose templates are the messages of d.
+func (b *Bundle) defaultTranslation(translationID string, d translation.Default) (translation.Translation, error) {
+	key := defaultKey{translationID, d}
+	b.RLock()
+	t := b.defaultTranslations[key]
+	b.RUnlock()
+	if t != nil {
+		return t, nil
+	}
+	context, id := translation.SplitKey(translationID)
+	t, err := d.Translation(context, id)
+	if err != nil {
+		return nil, err
+	}
+	b.Lock()
+	defer b.Unlock()
+	if b.defaultTranslations == nil {
+		b.defaultTranslations = make(map[defaultKey]translation.Translation)
+	}
+	b.defaultTranslations[key] = t
+	return t, nil
+}
+
+// defaultKey identifies a default message of a translation id.
+type defaultKey struct {
+	translationID string
+	message       translation.Default
+}
+
+// pseudoTranslation returns the pseudo-localized copy of t if SetPseudo was called, or else t.
+func (b *Bundle) pseudoTranslation(lang *language.Language, translationID string, t translation.Translation) translation.Translation {
+	b.RLock()
+	m, enabled, generation := b.pseudo, b.pseudoEnabled, b.pseudoGeneration
+	cached := b.pseudoTranslations[lang.Tag][translationID]
+	b.RUnlock()
+	if !enabled {
+		return t
+	}
+	if cached != nil {
+		return cached
+	}
+	pt, err := pseudo.Translation(t, m)
+	if err != nil {
+		return t
+	}
+	b.Lock()
+	defer b.Unlock()
+	// t may have been replaced since the lock was released.
+	if b.pseudoGeneration == generation {
+		if b.pseudoTranslations == nil {
+			b.pseudoTranslations = make(map[string]map[string]translation.Translation)
+		}
+		if b.pseudoTranslations[lang.Tag] == nil {
+			b.pseudoTranslations[lang.Tag] = make(map[string]translation.Translation)
 		}
+		b.pseudoTranslations[lang.Tag][translationID] = pt
 	}
-	return translations[translationID]
+	return pt
 }
 
 func isNumber(n interface{}) bool {
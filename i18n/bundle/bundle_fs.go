@@ -0,0 +1,84 @@
+//go:build go1.16
+// +build go1.16
+
+package bundle
+
+import (
+	"io/fs"
+	"path"
+)
+
+// LoadFS loads the translation files in fsys into memory.
+//
+// Only files whose path or base name match one of patterns (see path.Match) are loaded.
+// If no patterns are given, every file in fsys is loaded.
+// Files whose extension has no registered decoder (see RegisterFormat) are skipped.
+// The language of each file is parsed from its filename (e.g. en-US.json).
+//
+// LoadFS returns an error without loading any file if a pattern is malformed.
+// It keeps going when a file fails to load and returns a FileErrors
+// that lists every file that failed.
+//
+// It is useful for loading translation files embedded with go:embed.
+// It requires Go 1.16 or later.
+func (b *Bundle) LoadFS(fsys fs.FS, patterns ...string) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return err
+		}
+	}
+	var errs FileErrors
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			errs = append(errs, &FileError{name, err})
+			if d != nil && d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() || !decodable(path.Ext(name)) {
+			return nil
+		}
+		if !matchAny(name, patterns) {
+			return nil
+		}
+		buf, err := fs.ReadFile(fsys, name)
+		if err == nil {
+			err = b.ParseTranslationFileBytes(name, buf)
+		}
+		if err != nil {
+			errs = append(errs, &FileError{name, err})
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// MustLoadFS is similar to LoadFS except it panics if an error happens.
+func (b *Bundle) MustLoadFS(fsys fs.FS, patterns ...string) {
+	if err := b.LoadFS(fsys, patterns...); err != nil {
+		panic(err)
+	}
+}
+
+// matchAny reports whether name or its base name matches one of patterns, which are well-formed.
+func matchAny(name string, patterns []string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	base := path.Base(name)
+	for _, pattern := range patterns {
+		for _, s := range []string{name, base} {
+			if matched, _ := path.Match(pattern, s); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+//go:build go1.16
+// +build go1.16
+
+package bundle
+
+import (
+	"path"
+	"sort"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en-US.json":         {Data: []byte(`[{"id": "hello", "translation": "Hello"}]`)},
+		"locales/fr-FR.json": {Data: []byte(`{"hello": {"other": "Bonjour"}}`)},
+		"locales/README.md":  {Data: []byte(`not a translation file`)},
+	}
+
+	b := New()
+	if err := b.LoadFS(fsys, "*.json"); err != nil {
+		t.Fatal(err)
+	}
+	tags := b.LanguageTags()
+	sort.Strings(tags)
+	if len(tags) != 2 || tags[0] != "en-us" || tags[1] != "fr-fr" {
+		t.Errorf("LanguageTags() = %#v; expected en-us and fr-fr", tags)
+	}
+	tf := b.MustTfunc("fr-FR")
+	if actual := tf("hello"); actual != "Bonjour" {
+		t.Errorf("tf(hello) = %q; expected %q", actual, "Bonjour")
+	}
+}
+
+func TestLoadFSErrors(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en-US.json":   {Data: []byte(`[{"id": "hello", "translation": "Hello"}]`)},
+		"fr-FR.json":   {Data: []byte(`[{"id": "hello"`)},
+		"unknown.json": {Data: []byte(`[]`)},
+	}
+
+	b := New()
+	err := b.LoadFS(fsys)
+	errs, ok := err.(FileErrors)
+	if !ok {
+		t.Fatalf("LoadFS() = %#v; expected FileErrors", err)
+	}
+	var filenames []string
+	for _, fe := range errs {
+		filenames = append(filenames, fe.Filename)
+	}
+	sort.Strings(filenames)
+	if len(filenames) != 2 || filenames[0] != "fr-FR.json" || filenames[1] != "unknown.json" {
+		t.Errorf("failed files = %#v; expected fr-FR.json and unknown.json", filenames)
+	}
+	if tf := b.MustTfunc("en-US"); tf("hello") != "Hello" {
+		t.Errorf("expected en-US.json to be loaded despite other failures")
+	}
+}
+
+func TestLoadFSSkipsUnknownExtensions(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en-US.json":        {Data: []byte(`[{"id": "hello", "translation": "Hello"}]`)},
+		"README.md":         {Data: []byte(`not a translation file`)},
+		"locales/.DS_Store": {Data: []byte{0}},
+	}
+
+	b := New()
+	if err := b.LoadFS(fsys); err != nil {
+		t.Fatal(err)
+	}
+	if tags := b.LanguageTags(); len(tags) != 1 || tags[0] != "en-us" {
+		t.Errorf("LanguageTags() = %#v; expected en-us", tags)
+	}
+}
+
+func TestLoadFSBadPattern(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en-US.json": {Data: []byte(`[{"id": "hello", "translation": "Hello"}]`)},
+	}
+
+	b := New()
+	if err := b.LoadFS(fsys, "*.json", "["); err != path.ErrBadPattern {
+		t.Fatalf("LoadFS() = %#v; expected path.ErrBadPattern", err)
+	}
+	if tags := b.LanguageTags(); len(tags) != 0 {
+		t.Errorf("LanguageTags() = %#v; expected no files to be loaded", tags)
+	}
+}
@@ -3,6 +3,7 @@ package bundle
 import (
 	"fmt"
 	"strconv"
+	"strings"
 	"sync"
 	"testing"
 
@@ -10,6 +11,7 @@ import (
 	"sort"
 
 	"github.com/nicksnyder/go-i18n/i18n/language"
+	"github.com/nicksnyder/go-i18n/i18n/pseudo"
 	"github.com/nicksnyder/go-i18n/i18n/translation"
 )
 
@@ -25,6 +27,654 @@ func TestParseTranslationFileBytes(t *testing.T) {
 	t.Skipf("not implemented")
 }
 
+func TestParseTranslationFile(t *testing.T) {
+	src := []byte(`{"bye": {"other": "Goodbye {{.Person}"}, "hello": {"other": "Hello {{.Person}}"}, "open": {"context": "door", "other": "Open {{"}}`)
+	lang, translations, err := ParseTranslationFile("en-US.json", src)
+	if lang == nil || lang.Tag != "en-us" {
+		t.Fatalf("lang = %v; expected en-us", lang)
+	}
+	if len(translations) != 1 || translations[0].ID() != "hello" {
+		t.Errorf("translations = %v; expected hello", translations)
+	}
+	errs, ok := err.(TranslationErrors)
+	if !ok || len(errs) != 2 || errs[0].ID != "bye" || errs[1].ID != "open" || errs[1].Context != "door" {
+		t.Fatalf("err = %v; expected errors for bye and open", err)
+	}
+
+	b := New()
+	if err := b.ParseTranslationFileBytes("en-US.json", src); err == nil {
+		t.Errorf("ParseTranslationFileBytes() = nil error; expected error")
+	}
+	if tags := b.LanguageTags(); len(tags) != 0 {
+		t.Errorf("LanguageTags() = %v; expected no translations to be added", tags)
+	}
+
+	if lang, _, err := ParseTranslationFile("en-US.unknown", src); lang != nil || err == nil {
+		t.Errorf("ParseTranslationFile(en-US.unknown) = %v, %v; expected error", lang, err)
+	}
+}
+
+func TestRegisterFormat(t *testing.T) {
+	decode := func(buf []byte) (interface{}, error) {
+		data := make(map[string]interface{})
+		for _, line := range strings.Split(strings.TrimSpace(string(buf)), "\n") {
+			parts := strings.SplitN(line, "=", 2)
+			if len(parts) != 2 {
+				return nil, fmt.Errorf("invalid line %q", line)
+			}
+			data[parts[0]] = map[string]interface{}{"other": parts[1]}
+		}
+		return data, nil
+	}
+	RegisterFormat(".lines", decode, nil)
+
+	b := New()
+	if err := b.ParseTranslationFileBytes("en-US.lines", []byte("hello=Hello {{.Person}}\nbye=Goodbye")); err != nil {
+		t.Fatal(err)
+	}
+	tf := b.MustTfunc("en-US")
+	if actual := tf("hello", map[string]interface{}{"Person": "Bob"}); actual != "Hello Bob" {
+		t.Errorf("tf(hello) = %q; expected %q", actual, "Hello Bob")
+	}
+	if actual := tf("bye"); actual != "Goodbye" {
+		t.Errorf("tf(bye) = %q; expected %q", actual, "Goodbye")
+	}
+
+	if _, err := Marshal(".lines", nil); err == nil {
+		t.Errorf("Marshal(.lines) = nil error; expected error for format without encoder")
+	}
+	if err := b.ParseTranslationFileBytes("en-US.unknown", []byte("hello")); err == nil {
+		t.Errorf("ParseTranslationFileBytes(en-US.unknown) = nil error; expected error")
+	}
+}
+
+func TestParsePO(t *testing.T) {
+	po := `# Polish translations
+msgid ""
+msgstr ""
+"Language: pl\n"
+
+#. A comment for translators
+msgid "greeting"
+msgstr ""
+"Witaj "
+"{{.Person}}\n"
+
+msgctxt "verb"
+msgid "open"
+msgstr "otwórz"
+
+msgctxt "adjective"
+msgid "open"
+msgstr "otwarty"
+
+msgid "d_days"
+msgid_plural "d_days"
+msgstr[0] "{{.Count}} dzień"
+msgstr[1] "{{.Count}} dni"
+msgstr[2] "{{.Count}} dni"
+msgstr[3] "{{.Count}} dnia"
+
+#, fuzzy
+msgid "goodbye"
+msgstr "Do widzenia"
+
+#, fuzzy, go-format
+msgid "d_hours"
+msgid_plural "d_hours"
+msgstr[0] "{{.Count}} godzina"
+msgstr[1] "{{.Count}} godziny"
+
+#, go-format
+msgid "welcome"
+msgstr "Witamy"
+
+#~ msgid "obsolete"
+#~ msgstr "przestarzały"
+`
+	b := New()
+	if err := b.ParseTranslationFileBytes("pl.po", []byte(po)); err != nil {
+		t.Fatal(err)
+	}
+	tf := b.MustTfunc("pl")
+	tests := []struct {
+		id   string
+		args []interface{}
+		want string
+	}{
+		{"greeting", []interface{}{map[string]interface{}{"Person": "Bob"}}, "Witaj Bob\n"},
+		{"verb\x04open", nil, "otwórz"},
+		{"adjective\x04open", nil, "otwarty"},
+		{"d_days", []interface{}{1}, "1 dzień"},
+		{"d_days", []interface{}{2}, "2 dni"},
+		{"d_days", []interface{}{5}, "5 dni"},
+		{"d_days", []interface{}{"1.5"}, "1.5 dnia"},
+		{"obsolete", nil, "obsolete"},
+		{"goodbye", nil, "goodbye"},
+		{"d_hours", []interface{}{1}, "d_hours"},
+		{"welcome", nil, "Witamy"},
+	}
+	for _, test := range tests {
+		if actual := tf(test.id, test.args...); actual != test.want {
+			t.Errorf("tf(%q) = %q; expected %q", test.id, actual, test.want)
+		}
+	}
+	// Fuzzy entries are loaded as untranslated translations.
+	for _, id := range []string{"goodbye", "d_hours"} {
+		if tr := b.Translations()["pl"][id]; tr == nil || !tr.Incomplete(language.Parse("pl")[0]) {
+			t.Errorf("expected %s to be untranslated; got %#v", id, tr)
+		}
+	}
+
+	buf, err := Marshal("po", []interface{}{
+		map[string]interface{}{"id": "greeting", "translation": "Witaj\n\"{{.Person}}\"", "description": "Greets the signed in user"},
+		map[string]interface{}{"id": "open", "context": "verb", "translation": "otwórz"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `msgid ""
+msgstr ""
+"Content-Type: text/plain; charset=UTF-8\n"
+
+#. Greets the signed in user
+msgid "greeting"
+msgstr ""
+"Witaj\n"
+"\"{{.Person}}\""
+
+msgctxt "verb"
+msgid "open"
+msgstr "otwórz"
+`
+	if string(buf) != expected {
+		t.Errorf("Marshal(po) = %q; expected %q", buf, expected)
+	}
+
+	// Every plural category of the language has a msgstr[n], in the order of the Plural-Forms header.
+	buf, err = Marshal("po", &Catalog{Language: "pl", Translations: []interface{}{
+		map[string]interface{}{"id": "d_days", "translation": map[string]interface{}{"one": "{{.Count}} dzień", "other": "{{.Count}} dnia"}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = `msgid ""
+msgstr ""
+"Content-Type: text/plain; charset=UTF-8\n"
+"Language: pl\n"
+"Plural-Forms: nplurals=4; plural=(n == 1 ? 0 : (n % 10 >= 2 && n % 10 <= 4) && !(n % 100 >= 12 && n % 100 <= 14) ? 1 : (n != 1 && (n % 10 >= 0 && n % 10 <= 1)) || (n % 10 >= 5 && n % 10 <= 9) || (n % 100 >= 12 && n % 100 <= 14) ? 2 : 3);\n"
+
+msgid "d_days"
+msgid_plural "d_days"
+msgstr[0] "{{.Count}} dzień"
+msgstr[1] ""
+msgstr[2] ""
+msgstr[3] "{{.Count}} dnia"
+`
+	if string(buf) != expected {
+		t.Errorf("Marshal(po) = %q; expected %q", buf, expected)
+	}
+}
+
+func TestParsePOTooManyForms(t *testing.T) {
+	po := `msgid "d_days"
+msgid_plural "d_days"
+msgstr[0] "one"
+msgstr[1] "other"
+msgstr[2] "invalid"
+`
+	if err := New().ParseTranslationFileBytes("en.po", []byte(po)); err == nil {
+		t.Errorf("ParseTranslationFileBytes() = nil error; expected error for too many plural forms")
+	}
+}
+
+func TestParsePOPluralForms(t *testing.T) {
+	po := `msgid ""
+msgstr ""
+"Language: pl\n"
+"Plural-Forms: nplurals=2; plural=(n != 1);\n"
+
+msgid "hello"
+msgstr "Cześć"
+
+msgid "d_days"
+msgid_plural "d_days"
+msgstr[0] "{{.Count}} dzień"
+msgstr[1] "{{.Count}} dni"
+`
+	_, translations, err := ParseTranslationFile("pl.po", []byte(po))
+	errs, ok := err.(TranslationErrors)
+	if !ok || len(errs) != 1 || errs[0].ID != "d_days" {
+		t.Fatalf("ParseTranslationFile() = %v; expected an error for d_days", err)
+	}
+	if len(translations) != 1 || translations[0].ID() != "hello" {
+		t.Errorf("translations = %#v; expected the translation without plural forms", translations)
+	}
+
+	po = strings.Replace(po, "nplurals=2", "nplurals=two", 1)
+	if err := New().ParseTranslationFileBytes("pl.po", []byte(po)); err == nil {
+		t.Errorf("ParseTranslationFileBytes() = nil error; expected error for an invalid nplurals")
+	}
+}
+
+func TestGettextLanguage(t *testing.T) {
+	tests := map[string]string{
+		"pl":         "pl",
+		"de-de":      "de_DE",
+		"en-US":      "en_US",
+		"zh-hant-tw": "zh_Hant_TW",
+	}
+	for tag, expected := range tests {
+		if actual := gettextLanguage(tag); actual != expected {
+			t.Errorf("gettextLanguage(%q) = %q; expected %q", tag, actual, expected)
+		}
+	}
+}
+
+// xliffFiles are the XLIFF versions that tests write, with the extension of each file.
+// Files of either extension can hold either version.
+var xliffFiles = []struct{ version, ext string }{{XLIFF12, ".xlf"}, {XLIFF20, ".xliff"}}
+
+func TestParseXLIFF(t *testing.T) {
+	tests := []struct {
+		filename string
+		src      string
+	}{
+		{"fr-FR.xliff", `<?xml version="1.0" encoding="UTF-8"?>
+<xliff xmlns="urn:oasis:names:tc:xliff:document:1.2" version="1.2">
+  <file original="fr-FR" source-language="en-US" target-language="fr-FR" datatype="plaintext">
+    <body>
+      <group id="greetings">
+        <trans-unit id="person_greeting">
+          <source>Hello {{.Person}}</source>
+          <target>Bonjour {{.Person}}</target>
+        </trans-unit>
+      </group>
+      <trans-unit id="untranslated">
+        <source>Untranslated</source>
+      </trans-unit>
+      <group id="d_days" restype="x-gettext-plurals">
+        <trans-unit id="d_days[one]" resname="one">
+          <source>{{.Count}} day</source>
+          <target>{{.Count}} jour</target>
+        </trans-unit>
+        <trans-unit id="d_days[other]" resname="other">
+          <source>{{.Count}} days</source>
+          <target>{{.Count}} jours</target>
+        </trans-unit>
+      </group>
+    </body>
+  </file>
+</xliff>`},
+		{"fr-FR.xlf", `<?xml version="1.0" encoding="UTF-8"?>
+<xliff xmlns="urn:oasis:names:tc:xliff:document:2.0" version="2.0" srcLang="en-US" trgLang="fr-FR">
+  <file id="f1">
+    <unit id="u1" name="person_greeting">
+      <segment><source>Hello </source><target>Bonjour </target></segment>
+      <segment><source>{{.Person}}</source><target>{{.Person}}</target></segment>
+    </unit>
+    <unit id="u2" name="untranslated">
+      <segment><source>Untranslated</source></segment>
+    </unit>
+    <group id="g1" name="d_days" type="i18n:plural">
+      <unit id="u3" name="one"><segment><source>{{.Count}} day</source><target>{{.Count}} jour</target></segment></unit>
+      <unit id="u4" name="other"><segment><source>{{.Count}} days</source><target>{{.Count}} jours</target></segment></unit>
+    </group>
+  </file>
+</xliff>`},
+	}
+	for _, test := range tests {
+		b := New()
+		if err := b.ParseTranslationFileBytes(test.filename, []byte(test.src)); err != nil {
+			t.Fatalf("%s: %s", test.filename, err)
+		}
+		tf := b.MustTfunc("fr-FR")
+		if actual := tf("person_greeting", map[string]interface{}{"Person": "Bob"}); actual != "Bonjour Bob" {
+			t.Errorf("%s: tf(person_greeting) = %q; expected %q", test.filename, actual, "Bonjour Bob")
+		}
+		if actual := tf("untranslated"); actual != "untranslated" {
+			t.Errorf("%s: tf(untranslated) = %q; expected %q", test.filename, actual, "untranslated")
+		}
+		if actual := tf("d_days", 1); actual != "1 jour" {
+			t.Errorf("%s: tf(d_days, 1) = %q; expected %q", test.filename, actual, "1 jour")
+		}
+		if actual := tf("d_days", 2); actual != "2 jours" {
+			t.Errorf("%s: tf(d_days, 2) = %q; expected %q", test.filename, actual, "2 jours")
+		}
+	}
+
+	// srcLang is required in XLIFF 2.0 and does not exist in XLIFF 1.2.
+	for _, file := range xliffFiles {
+		buf, err := MarshalXLIFF(file.version, []interface{}{map[string]interface{}{"id": "hello", "translation": "Hello"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hasSrcLang := strings.Contains(string(buf), "srcLang="); hasSrcLang != (file.version == XLIFF20) {
+			t.Errorf("XLIFF %s: srcLang is written: %t\n%s", file.version, hasSrcLang, buf)
+		}
+	}
+}
+
+func TestParseICU(t *testing.T) {
+	tests := []struct {
+		filename string
+		src      string
+	}{
+		{"en-US.json", `[
+			{"id": "items", "syntax": "icu", "translation": "{Count, plural, one {# item} other {# items}}"},
+			{"id": "greeting", "syntax": "icu", "translation": "{Gender, select, female {Hello {Person}, she said} other {Hello {Person}, they said}}"},
+			{"id": "template", "translation": "Hello {{.Person}}"}
+		]`},
+		{"en-US.json", `{
+			"items": {"syntax": "icu", "other": "{Count, plural, one {# item} other {# items}}"},
+			"greeting": {"syntax": "icu", "other": "{Gender, select, female {Hello {Person}, she said} other {Hello {Person}, they said}}"},
+			"template": {"other": "Hello {{.Person}}"}
+		}`},
+		{"en-US.icu.json", `{
+			"items": {"other": "{Count, plural, one {# item} other {# items}}"},
+			"greeting": {"other": "{Gender, select, female {Hello {Person}, she said} other {Hello {Person}, they said}}"},
+			"template": {"syntax": "go", "other": "Hello {{.Person}}"}
+		}`},
+	}
+	for _, test := range tests {
+		b := New()
+		if err := b.ParseTranslationFileBytes(test.filename, []byte(test.src)); err != nil {
+			t.Fatalf("%s: %s", test.filename, err)
+		}
+		tf := b.MustTfunc("en-US")
+		if actual := tf("items", 1); actual != "1 item" {
+			t.Errorf("%s: tf(items, 1) = %q; expected %q", test.filename, actual, "1 item")
+		}
+		if actual := tf("items", 2); actual != "2 items" {
+			t.Errorf("%s: tf(items, 2) = %q; expected %q", test.filename, actual, "2 items")
+		}
+		args := map[string]interface{}{"Gender": "female", "Person": "Bob"}
+		if actual := tf("greeting", args); actual != "Hello Bob, she said" {
+			t.Errorf("%s: tf(greeting) = %q; expected %q", test.filename, actual, "Hello Bob, she said")
+		}
+		if actual := tf("template", args); actual != "Hello Bob" {
+			t.Errorf("%s: tf(template) = %q; expected %q", test.filename, actual, "Hello Bob")
+		}
+	}
+
+	if err := New().ParseTranslationFileBytes("en-US.icu.json", []byte(`{"items": {"one": "{Count} item", "other": "{Count} items"}}`)); err == nil {
+		t.Errorf("expected an error for a plural translation in an ICU file")
+	}
+}
+
+func TestParseOrdinal(t *testing.T) {
+	tests := []struct {
+		filename string
+		src      string
+	}{
+		{"en-US.json", `[{"id": "place", "ordinal": true, "translation": {"one": "{{.Count}}st", "two": "{{.Count}}nd", "few": "{{.Count}}rd", "other": "{{.Count}}th"}}]`},
+		{"en-US.json", `{"place": {"ordinal": true, "one": "{{.Count}}st", "two": "{{.Count}}nd", "few": "{{.Count}}rd", "other": "{{.Count}}th"}}`},
+	}
+
+	// XLIFF keeps ordinal translations in groups of their own; gettext PO has no ordinal forms.
+	b := New()
+	if err := b.ParseTranslationFileBytes(tests[0].filename, []byte(tests[0].src)); err != nil {
+		t.Fatal(err)
+	}
+	translations := []interface{}{b.Translations()["en-us"]["place"].MarshalInterface()}
+	for _, file := range xliffFiles {
+		buf, err := MarshalXLIFF(file.version, translations)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tests = append(tests, struct {
+			filename string
+			src      string
+		}{"en-US" + file.ext, string(buf)})
+	}
+	if _, err := Marshal(".xlf", translations); err == nil {
+		t.Error("expected an error for XLIFF without a version")
+	}
+	if _, err := MarshalXLIFF("3.0", translations); err == nil {
+		t.Error("expected an error for an unsupported XLIFF version")
+	}
+	if _, err := Marshal("po", translations); err == nil {
+		t.Error("expected an error for an ordinal translation in PO")
+	}
+
+	for _, test := range tests {
+		b := New()
+		if err := b.ParseTranslationFileBytes(test.filename, []byte(test.src)); err != nil {
+			t.Fatalf("%s: %s", test.filename, err)
+		}
+		tf := b.MustTfunc("en-US")
+		for count, expected := range map[int]string{1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 11: "11th", 22: "22nd"} {
+			if actual := tf("place", count); actual != expected {
+				t.Errorf("%s: tf(place, %d) = %q; expected %q", test.filename, count, actual, expected)
+			}
+		}
+	}
+
+	// German ordinals only use "other", but the translation is still an ordinal translation.
+	b = New()
+	if err := b.ParseTranslationFileBytes("de.json", []byte(`{"place": {"ordinal": true, "other": "{{.Count}}."}}`)); err != nil {
+		t.Fatal(err)
+	}
+	if tr := b.Translations()["de"]["place"]; tr == nil || !tr.Ordinal() {
+		t.Errorf("expected an ordinal translation; got %#v", tr)
+	}
+	if actual := b.MustTfunc("de")("place", 1); actual != "1." {
+		t.Errorf("tf(place, 1) = %q; expected %q", actual, "1.")
+	}
+}
+
+func TestParseSelect(t *testing.T) {
+	tests := []struct {
+		filename string
+		src      string
+	}{
+		{"en-US.json", `[{"id": "invited", "select": "Gender", "translation": {"female": "{{.Person}} invited you to her party.", "other": "{{.Person}} invited you to their party."}}, {"id": "photos", "select": "Gender", "translation": {"female": {"one": "her {{.Count}} photo", "other": "her {{.Count}} photos"}, "other": {"one": "their {{.Count}} photo", "other": "their {{.Count}} photos"}}}]`},
+		{"en-US.json", `{"invited": {"select": "Gender", "female": "{{.Person}} invited you to her party.", "other": "{{.Person}} invited you to their party."}, "photos": {"select": "Gender", "female": {"one": "her {{.Count}} photo", "other": "her {{.Count}} photos"}, "other": {"one": "their {{.Count}} photo", "other": "their {{.Count}} photos"}}}`},
+		{"en-US.yaml", "invited:\n  select: Gender\n  female: \"{{.Person}} invited you to her party.\"\n  other: \"{{.Person}} invited you to their party.\"\nphotos:\n  select: Gender\n  female:\n    one: \"her {{.Count}} photo\"\n    other: \"her {{.Count}} photos\"\n  other:\n    one: \"their {{.Count}} photo\"\n    other: \"their {{.Count}} photos\"\n"},
+	}
+	for _, test := range tests {
+		b := New()
+		if err := b.ParseTranslationFileBytes(test.filename, []byte(test.src)); err != nil {
+			t.Fatalf("%s: %s", test.filename, err)
+		}
+		tf := b.MustTfunc("en-US")
+		translations := []struct {
+			id       string
+			args     []interface{}
+			expected string
+		}{
+			{"invited", []interface{}{map[string]interface{}{"Person": "Alice", "Gender": "female"}}, "Alice invited you to her party."},
+			{"invited", []interface{}{struct{ Person, Gender string }{"Bob", "male"}}, "Bob invited you to their party."},
+			{"invited", []interface{}{map[string]interface{}{"Person": "Sam"}}, "Sam invited you to their party."},
+			{"photos", []interface{}{1, map[string]interface{}{"Gender": "female"}}, "her 1 photo"},
+			{"photos", []interface{}{2, map[string]interface{}{"Gender": "female"}}, "her 2 photos"},
+			{"photos", []interface{}{1}, "their 1 photo"},
+		}
+		for _, tr := range translations {
+			if actual := tf(tr.id, tr.args...); actual != tr.expected {
+				t.Errorf("%s: tf(%s, %v) = %q; expected %q", test.filename, tr.id, tr.args, actual, tr.expected)
+			}
+		}
+	}
+
+	if err := New().ParseTranslationFileBytes("en-US.json", []byte(`[{"id": "invited", "select": "Gender", "translation": {"female": "her party"}}]`)); err == nil {
+		t.Errorf("expected an error for a select translation without an other variant")
+	}
+	if _, err := Marshal("po", []interface{}{map[string]interface{}{"id": "invited", "select": "Gender", "translation": map[string]interface{}{"other": "their party"}}}); err == nil {
+		t.Errorf("expected an error when marshaling a select translation to po")
+	}
+}
+
+func TestParseFlatContext(t *testing.T) {
+	src := []byte(`{"verb\u0004open": {"context": "verb", "other": "Öffnen"}, "adjective\u0004open": {"context": "door", "other": "Offen"}, "close": {"context": "verb", "other": "Schließen"}}`)
+	_, translations, err := ParseTranslationFile("de.json", src)
+	errs, ok := err.(TranslationErrors)
+	if !ok || len(errs) != 1 || errs[0].ID != "open" || errs[0].Context != "adjective" {
+		t.Fatalf("err = %v; expected an error for the context of adjective\x04open", err)
+	}
+	var keys []string
+	for _, tr := range translations {
+		keys = append(keys, translation.KeyOf(tr))
+	}
+	sort.Strings(keys)
+	if expected := []string{"verb\x04close", "verb\x04open"}; !reflect.DeepEqual(keys, expected) {
+		t.Errorf("keys = %q; expected %q", keys, expected)
+	}
+}
+
+func TestParseMetadata(t *testing.T) {
+	tests := []struct {
+		filename string
+		src      string
+	}{
+		{"en-US.json", `[{"id": "open", "description": "Opens a file", "context": "menu item", "maxLength": 10, "translation": "Open"}, {"id": "files", "description": "Number of files", "translation": {"one": "{{.Count}} file", "other": "{{.Count}} files"}}]`},
+		{"en-US.json", `{"open": {"description": "Opens a file", "context": "menu item", "maxLength": 10, "other": "Open"}, "files": {"description": "Number of files", "one": "{{.Count}} file", "other": "{{.Count}} files"}}`},
+		{"en-US.yaml", "open:\n  description: Opens a file\n  context: menu item\n  maxLength: 10\n  other: Open\nfiles:\n  description: Number of files\n  one: \"{{.Count}} file\"\n  other: \"{{.Count}} files\"\n"},
+		{"en-US.toml", "[open]\ndescription = \"Opens a file\"\ncontext = \"menu item\"\nmaxLength = 10\nother = \"Open\"\n\n[files]\ndescription = \"Number of files\"\none = \"{{.Count}} file\"\nother = \"{{.Count}} files\"\n"},
+	}
+
+	// XLIFF keeps the metadata in notes (and a maxwidth in XLIFF 1.2).
+	b := New()
+	if err := b.ParseTranslationFileBytes(tests[0].filename, []byte(tests[0].src)); err != nil {
+		t.Fatal(err)
+	}
+	var translations []interface{}
+	for _, id := range b.LanguageTranslationIDs("en-us") {
+		translations = append(translations, b.Translations()["en-us"][id].MarshalInterface())
+	}
+	for _, file := range xliffFiles {
+		buf, err := MarshalXLIFF(file.version, translations)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tests = append(tests, struct {
+			filename string
+			src      string
+		}{"en-US" + file.ext, string(buf)})
+	}
+
+	for _, test := range tests {
+		b := New()
+		if err := b.ParseTranslationFileBytes(test.filename, []byte(test.src)); err != nil {
+			t.Fatalf("%s: %s", test.filename, err)
+		}
+		translations := b.Translations()["en-us"]
+		expected := translation.Metadata{Description: "Opens a file", Context: "menu item", MaxLength: 10}
+		if actual := translations[translation.Key("menu item", "open")].Metadata(); actual != expected {
+			t.Errorf("%s: Metadata() = %#v; expected %#v", test.filename, actual, expected)
+		}
+		if actual := translations["files"].Metadata().Description; actual != "Number of files" {
+			t.Errorf("%s: Metadata().Description = %q; expected %q", test.filename, actual, "Number of files")
+		}
+		if actual := b.MustTfunc("en-US")("files", 2); actual != "2 files" {
+			t.Errorf("%s: tf(files, 2) = %q; expected %q", test.filename, actual, "2 files")
+		}
+	}
+
+	if err := New().ParseTranslationFileBytes("en-US.json", []byte(`[{"id": "open", "maxLength": "ten", "translation": "Open"}]`)); err == nil {
+		t.Errorf("expected an error for a maxLength that is not a number")
+	}
+}
+
+func TestContext(t *testing.T) {
+	tests := []struct {
+		filename string
+		src      string
+	}{
+		{"de.json", `[{"id": "open", "context": "verb", "translation": "Öffnen"}, {"id": "open", "context": "adjective", "translation": "Offen"}, {"id": "open", "translation": "Auf"}]`},
+		{"de.json", `{"verb\u0004open": {"other": "Öffnen"}, "adjective\u0004open": {"other": "Offen"}, "open": {"other": "Auf"}}`},
+		{"de.po", "msgctxt \"verb\"\nmsgid \"open\"\nmsgstr \"Öffnen\"\n\nmsgctxt \"adjective\"\nmsgid \"open\"\nmsgstr \"Offen\"\n\nmsgid \"open\"\nmsgstr \"Auf\"\n"},
+	}
+	for _, file := range xliffFiles {
+		buf, err := MarshalXLIFF(file.version, []interface{}{
+			map[string]interface{}{"id": "open", "context": "verb", "translation": "Öffnen"},
+			map[string]interface{}{"id": "open", "context": "adjective", "translation": "Offen"},
+			map[string]interface{}{"id": "open", "translation": "Auf"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		tests = append(tests, struct {
+			filename string
+			src      string
+		}{"de" + file.ext, string(buf)})
+	}
+
+	// The flat format keys translations with a context by translation.Key.
+	b := New()
+	if err := b.ParseTranslationFileBytes(tests[0].filename, []byte(tests[0].src)); err != nil {
+		t.Fatal(err)
+	}
+	flat := make(map[string]interface{})
+	for key, translation := range b.Translations()["de"] {
+		flat[key] = translation.MarshalFlatInterface()
+	}
+	for _, format := range []string{"json", "yaml", "toml"} {
+		buf, err := Marshal(format, flat)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tests = append(tests, struct {
+			filename string
+			src      string
+		}{"de." + format, string(buf)})
+	}
+
+	for _, test := range tests {
+		b := New()
+		if err := b.ParseTranslationFileBytes(test.filename, []byte(test.src)); err != nil {
+			t.Fatalf("%s: %s", test.filename, err)
+		}
+		tf := b.MustContextTfunc("de")
+		for _, translation := range []struct{ context, id, expected string }{
+			{"verb", "open", "Öffnen"},
+			{"adjective", "open", "Offen"},
+			{"", "open", "Auf"},
+			{"noun", "open", "open"},
+		} {
+			if actual := tf(translation.context, translation.id); actual != translation.expected {
+				t.Errorf("%s: tf(%q, %q) = %q; expected %q", test.filename, translation.context, translation.id, actual, translation.expected)
+			}
+		}
+		if actual := b.MustTfunc("de")("open"); actual != "Auf" {
+			t.Errorf("%s: tf(open) = %q; expected %q", test.filename, actual, "Auf")
+		}
+	}
+}
+
+func TestTranslateRange(t *testing.T) {
+	b := New()
+	if err := b.ParseTranslationFileBytes("fr-FR.json", []byte(`[{"id": "d_days", "translation": {"one": "{{.Start}}–{{.End}} jour", "other": "{{.Start}}–{{.End}} jours"}}]`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.ParseTranslationFileBytes("en-US.json", []byte(`[{"id": "d_days", "translation": {"one": "{{.Start}}–{{.End}} day", "other": "{{.Start}}–{{.End}} days in {{.Month}}"}}]`)); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		lang        string
+		numberRange language.Range
+		data        interface{}
+		expected    string
+	}{
+		{"fr-FR", language.Range{Start: 0, End: 1}, nil, "0–1 jour"},
+		{"fr-FR", language.Range{Start: 1, End: 3}, nil, "1–3 jours"},
+		{"en-US", language.Range{Start: 0, End: 1}, map[string]interface{}{"Month": "May"}, "0–1 days in May"},
+		{"en-US", language.Range{Start: "1.5", End: "2"}, struct{ Month string }{"June"}, "1.5–2 days in June"},
+	}
+	for _, test := range tests {
+		tf := b.MustTfunc(test.lang)
+		var actual string
+		if test.data == nil {
+			actual = tf("d_days", test.numberRange)
+		} else {
+			actual = tf("d_days", test.numberRange, test.data)
+		}
+		if actual != test.expected {
+			t.Errorf("%s: tf(d_days, %v) = %q; expected %q", test.lang, test.numberRange, actual, test.expected)
+		}
+	}
+}
+
 func TestAddTranslation(t *testing.T) {
 	t.Skipf("not implemented")
 }
@@ -112,9 +762,28 @@ func TestTfuncAndLanguage(t *testing.T) {
 		},
 		{
 			[]string{"zh-CN,fr-XX,es"},
+			chineseTranslation,
+
+			// "zh-CN" has the likely subtags of "zh-hans-cn"
+			languageWithTag("zh-CN"),
+		},
+		{
+			[]string{"zh-TW,de-XX,es"},
+			spanishTranslation,
+
+			// "zh-TW" is written in the Traditional script, unlike "zh-hans-cn"
+			spanishLanguage,
+		},
+		{
+			[]string{"en-US;q=0.5, es;q=0.8, fr-FR;q=0"},
 			spanishTranslation,
 			spanishLanguage,
 		},
+		{
+			[]string{"fr-FR;q=0, *"},
+			translationID,
+			nil,
+		},
 		{
 			[]string{"fr"},
 			frenchTranslation,
@@ -122,6 +791,13 @@ func TestTfuncAndLanguage(t *testing.T) {
 			// The language is still "fr" even though the translation is provided by "fr-FR"
 			languageWithTag("fr"),
 		},
+		{
+			[]string{"fr-CA"},
+			frenchTranslation,
+
+			// The parent language "fr" of "fr-CA" is provided by "fr-FR"
+			languageWithTag("fr-CA"),
+		},
 		{
 			[]string{"zh"},
 			chineseTranslation,
@@ -362,3 +1038,343 @@ func BenchmarkTranslatePluralWithStructPointer(b *testing.B) {
 		tf(data)
 	}
 }
+
+func TestPseudo(t *testing.T) {
+	b := New()
+	if err := b.ParseTranslationFileBytes("en-US.json", []byte(`[{"id": "hello", "translation": "Hello {{.Person}}"}]`)); err != nil {
+		t.Fatal(err)
+	}
+	tf := b.MustTfunc("en-US")
+	data := map[string]interface{}{"Person": "Bob"}
+	b.SetPseudo(pseudo.Accented)
+	if actual, expected := tf("hello", data), "[Ĥéļļö Bob one]"; actual != expected {
+		t.Errorf("tf(hello) = %q; expected %q", actual, expected)
+	}
+	b.SetPseudo(pseudo.Bidi)
+	if actual, expected := tf("hello", data), "\u200f\u202eHello\u202c\u200f Bob"; actual != expected {
+		t.Errorf("tf(hello) = %q; expected %q", actual, expected)
+	}
+	b.ClearPseudo()
+	if actual, expected := tf("hello", data), "Hello Bob"; actual != expected {
+		t.Errorf("tf(hello) = %q; expected %q", actual, expected)
+	}
+}
+
+func TestOnMissing(t *testing.T) {
+	b := New()
+	if err := b.ParseTranslationFileBytes("en-US.json", []byte(`[
+		{"id": "hello", "translation": "Hello"},
+		{"id": "untranslated", "translation": ""},
+		{"id": "files", "translation": {"other": "{{.Count}} files"}}
+	]`)); err != nil {
+		t.Fatal(err)
+	}
+	type missing struct {
+		lang   string
+		id     string
+		reason MissingReason
+	}
+	var actual []missing
+	b.OnMissing(func(lang *language.Language, translationID string, reason MissingReason) {
+		tag := ""
+		if lang != nil {
+			tag = lang.Tag
+		}
+		actual = append(actual, missing{tag, translationID, reason})
+	})
+
+	tf := b.MustTfunc("en-US")
+	tf("hello")
+	tf("goodbye")
+	tf("untranslated")
+	tf("files", 1)
+	tf("files", 2)
+	b.MustContextTfunc("en-US")("verb", "open")
+	if unknown, err := b.Tfunc("fr-FR"); err == nil {
+		t.Error("expected an error for an unknown language")
+	} else {
+		unknown("hello")
+	}
+
+	expected := []missing{
+		{"en-us", "goodbye", MissingID},
+		{"en-us", "untranslated", TemplateFailed},
+		{"en-us", "files", MissingPluralCategory},
+		{"en-us", translation.Key("verb", "open"), MissingID},
+		{"", "hello", MissingID},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("missing = %v; expected %v", actual, expected)
+	}
+
+	b.OnMissing(nil)
+	tf("goodbye")
+	if len(actual) != len(expected) {
+		t.Errorf("OnMissing(nil) did not remove the function")
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	b := New()
+	if err := b.ParseTranslationFileBytes("en-US.json", []byte(`[
+		{"id": "hello", "translation": "Hello {{.Person}}"},
+		{"id": "untranslated", "translation": ""},
+		{"id": "files", "translation": {"other": "{{.Count}} files"}},
+		{"id": "broken", "translation": "{{index .Items 3}}"}
+	]`)); err != nil {
+		t.Fatal(err)
+	}
+	en := language.MustParse("en-US")[0]
+
+	s, err := b.Localize(en, "hello", map[string]interface{}{"Person": "Bob"})
+	if err != nil || s != "Hello Bob" {
+		t.Errorf("Localize(hello) = %q, %v; expected %q, nil", s, err, "Hello Bob")
+	}
+
+	tests := []struct {
+		lang          *language.Language
+		translationID string
+		args          []interface{}
+		err           error
+	}{
+		{en, "goodbye", nil, ErrMessageNotFound},
+		{nil, "hello", nil, ErrMessageNotFound},
+		{en, "files", []interface{}{1}, ErrPluralCategoryMissing},
+		{en, "untranslated", nil, ErrEmptyMessage},
+	}
+	for _, test := range tests {
+		s, err := b.Localize(test.lang, test.translationID, test.args...)
+		te, ok := err.(*TranslateError)
+		if s != "" || !ok || te.Err != test.err || te.TranslationID != test.translationID || te.Language != test.lang {
+			t.Errorf("Localize(%v, %s) = %q, %#v; expected a TranslateError for %v", test.lang, test.translationID, s, err, test.err)
+		}
+	}
+
+	_, err = b.Localize(en, "broken", map[string]interface{}{"Items": []string{}})
+	if te, ok := err.(*TranslateError); !ok || te.Err == nil || te.Unwrap() != te.Err || te.Err == ErrEmptyMessage {
+		t.Errorf("expected a TranslateError that wraps the template error but got %#v", err)
+	}
+	if actual := b.MustTfunc("en-US")("broken", map[string]interface{}{"Items": []string{}}); actual != err.(*TranslateError).Err.Error() {
+		t.Errorf("TranslateFunc returned %q; expected the template error", actual)
+	}
+}
+
+func TestDefault(t *testing.T) {
+	b := New()
+	if err := b.ParseTranslationFileBytes("fr-FR.json", []byte(`[{"id": "hello", "translation": "Bonjour {{.Person}}"}]`)); err != nil {
+		t.Fatal(err)
+	}
+	var missing []string
+	b.OnMissing(func(lang *language.Language, translationID string, reason MissingReason) {
+		missing = append(missing, translationID)
+	})
+	hello := translation.Default{Other: "Hello {{.Person}}"}
+	files := translation.Default{One: "{{.Count}} file", Other: "{{.Count}} files"}
+	data := map[string]interface{}{"Person": "Bob"}
+
+	tf := b.MustTfunc("fr-FR")
+	tests := []struct {
+		actual, expected string
+	}{
+		{tf("hello", hello, data), "Bonjour Bob"},
+		{tf("goodbye", translation.Default{Other: "Goodbye {{.Person}}"}, data), "Goodbye Bob"},
+		{tf("files", files, 1), "1 file"},
+		{tf("files", files, 2), "2 files"},
+		{b.MustContextTfunc("fr-FR")("verb", "open", translation.Default{Other: "Open"}), "Open"},
+	}
+	ar := language.MustParse("ar")[0]
+	s, err := b.Localize(ar, "files", files, 3)
+	tests = append(tests, struct{ actual, expected string }{s, "3 files"})
+	if err != nil {
+		t.Error(err)
+	}
+	s, err = b.Localize(nil, "hello", hello, data)
+	tests = append(tests, struct{ actual, expected string }{s, "Hello Bob"})
+	if err != nil {
+		t.Error(err)
+	}
+	for _, test := range tests {
+		if test.actual != test.expected {
+			t.Errorf("got %q; expected %q", test.actual, test.expected)
+		}
+	}
+	if len(missing) > 0 {
+		t.Errorf("expected no missing translations but got %v", missing)
+	}
+}
+
+func TestFallback(t *testing.T) {
+	b := New()
+	files := map[string]string{
+		"en-US.json": `[
+			{"id": "hello", "translation": "Hello"},
+			{"id": "color", "translation": "Color"},
+			{"id": "files", "translation": {"one": "{{.Count}} file", "other": "{{.Count}} files"}}
+		]`,
+		"fr.json":    `[{"id": "hello", "translation": "Bonjour"}, {"id": "color", "translation": "Couleur"}]`,
+		"fr-CA.json": `[{"id": "hello", "translation": "Allô"}, {"id": "files", "translation": {"one": "", "other": ""}}]`,
+		"pt-PT.json": `[{"id": "hello", "translation": "Olá"}]`,
+		"de.json":    `[{"id": "color", "translation": "Farbe"}]`,
+	}
+	for filename, content := range files {
+		if err := b.ParseTranslationFileBytes(filename, []byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := b.SetDefaultLanguage("en-US"); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.AddFallback("pt-BR", "pt-PT"); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.AddFallback("invalid", "pt-PT"); err == nil {
+		t.Error("AddFallback(invalid) = nil; expected error")
+	}
+
+	tests := []struct {
+		prefs            []string
+		translationID    string
+		args             []interface{}
+		expected         string
+		expectedLanguage string
+	}{
+		{[]string{"fr-CA"}, "hello", nil, "Allô", "fr-ca"},
+		{[]string{"fr-CA"}, "color", nil, "Couleur", "fr-ca"},
+		{[]string{"fr-CA"}, "files", []interface{}{2}, "2 files", "fr-ca"},
+		{[]string{"pt-BR"}, "hello", nil, "Olá", "pt-br"},
+		{[]string{"pt-BR"}, "color", nil, "Color", "pt-br"},
+		{[]string{"fr-CA", "de"}, "color", nil, "Couleur", "fr-ca"},
+		{[]string{"de", "fr-CA"}, "hello", nil, "Allô", "de"},
+		{[]string{"ja"}, "hello", nil, "Hello", "en-us"},
+	}
+	for _, test := range tests {
+		tf, lang, err := b.TfuncAndLanguage(test.prefs[0], test.prefs[1:]...)
+		if err != nil {
+			t.Errorf("TfuncAndLanguage(%v) = error{%q}; expected no error", test.prefs, err)
+			continue
+		}
+		if lang.Tag != test.expectedLanguage {
+			t.Errorf("TfuncAndLanguage(%v) language = %s; expected %s", test.prefs, lang, test.expectedLanguage)
+		}
+		if actual := tf(test.translationID, test.args...); actual != test.expected {
+			t.Errorf("TfuncAndLanguage(%v)(%s) = %q; expected %q", test.prefs, test.translationID, actual, test.expected)
+		}
+	}
+
+	s, err := b.Localize(language.MustParse("fr-CA")[0], "color")
+	if err != nil || s != "Couleur" {
+		t.Errorf("Localize(fr-CA, color) = %q, %v; expected %q, nil", s, err, "Couleur")
+	}
+	if s := b.Translate(language.MustParse("pt-BR")[0], "hello"); s != "Olá" {
+		t.Errorf("Translate(pt-BR, hello) = %q; expected %q", s, "Olá")
+	}
+	if s := b.Translate(nil, "hello"); s != "Hello" {
+		t.Errorf("Translate(nil, hello) = %q; expected %q", s, "Hello")
+	}
+}
+
+func TestScriptFallback(t *testing.T) {
+	b := New()
+	files := map[string]string{
+		"zh-Hant.json": `[{"id": "hello", "translation": "你好（繁體）"}]`,
+		"zh.json":      `[{"id": "hello", "translation": "你好"}, {"id": "bye", "translation": "再见"}]`,
+		"sr.json":      `[{"id": "hello", "translation": "Здраво"}]`,
+		"sr-Latn.json": `[{"id": "bye", "translation": "Zbogom"}]`,
+	}
+	for filename, content := range files {
+		if err := b.ParseTranslationFileBytes(filename, []byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tests := []struct {
+		pref, translationID, expected string
+	}{
+		{"zh-TW", "hello", "你好（繁體）"},
+		{"zh-HK", "hello", "你好（繁體）"},
+		{"zh-Hant-TW", "hello", "你好（繁體）"},
+		{"zh-TW", "bye", "bye"},
+		{"zh-CN", "hello", "你好"},
+		{"zh-Hans", "bye", "再见"},
+		{"sr-RS", "hello", "Здраво"},
+		{"sr-Latn-RS", "bye", "Zbogom"},
+		{"sr-ME", "bye", "Zbogom"},
+		{"sr-Latn", "hello", "hello"},
+	}
+	for _, test := range tests {
+		if actual := b.MustTfunc(test.pref)(test.translationID); actual != test.expected {
+			t.Errorf("Tfunc(%s)(%s) = %q; expected %q", test.pref, test.translationID, actual, test.expected)
+		}
+	}
+}
+
+func TestLikelySubtagsMatching(t *testing.T) {
+	b := New()
+	files := map[string]string{
+		"zh.json":         `[{"id": "hello", "translation": "你好"}]`,
+		"sr-Latn-RS.json": `[{"id": "hello", "translation": "Zdravo"}]`,
+	}
+	for filename, content := range files {
+		if err := b.ParseTranslationFileBytes(filename, []byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tests := []struct {
+		pref, expected string
+	}{
+		// zh-TW is Traditional Chinese, so it does not match zh, which is Simplified Chinese.
+		{"zh-TW", "hello"},
+		{"zh-CN", "你好"},
+		// sr-Latn expands to its likely region, sr-Latn-RS.
+		{"sr-Latn", "Zdravo"},
+		{"sr", "hello"},
+	}
+	for _, test := range tests {
+		tf, _ := b.Tfunc(test.pref)
+		if actual := tf("hello"); actual != test.expected {
+			t.Errorf("Tfunc(%s)(hello) = %q; expected %q", test.pref, actual, test.expected)
+		}
+	}
+}
+
+func TestSetTranslations(t *testing.T) {
+	b := New()
+	en := language.Parse("en")[0]
+	enUS := language.Parse("en-US")[0]
+	b.AddTranslation(enUS, testNewTranslation(t, map[string]interface{}{"id": "hello", "translation": "Hello"}))
+	b.AddTranslation(enUS, testNewTranslation(t, map[string]interface{}{"id": "bye", "translation": "Bye"}))
+	b.AddTranslation(en, testNewTranslation(t, map[string]interface{}{"id": "hello", "translation": "Hi"}))
+
+	b.SetTranslations(enUS, testNewTranslation(t, map[string]interface{}{"id": "hello", "translation": "Hello!"}))
+	if ids := b.LanguageTranslationIDs("en-us"); !reflect.DeepEqual(ids, []string{"hello"}) {
+		t.Errorf("LanguageTranslationIDs(en-US) = %v; expected [hello]", ids)
+	}
+	tests := []struct {
+		pref, translationID, expected string
+	}{
+		{"en-US", "hello", "Hello!"},
+		{"en-US", "bye", "bye"},
+		{"en", "hello", "Hi"},
+		{"en-GB", "hello", "Hi"},
+	}
+	for _, test := range tests {
+		if actual := b.MustTfunc(test.pref)(test.translationID); actual != test.expected {
+			t.Errorf("Tfunc(%s)(%s) = %q; expected %q", test.pref, test.translationID, actual, test.expected)
+		}
+	}
+
+	b.SetTranslations(en)
+	if tags := b.LanguageTags(); !reflect.DeepEqual(tags, []string{"en-us"}) {
+		t.Errorf("LanguageTags() = %v; expected [en-us]", tags)
+	}
+	if actual := b.MustTfunc("en-GB")("hello"); actual != "Hello!" {
+		t.Errorf("Tfunc(en-GB)(hello) = %q; expected %q", actual, "Hello!")
+	}
+
+	b.SetTranslations(enUS)
+	if tags := b.LanguageTags(); len(tags) != 0 {
+		t.Errorf("LanguageTags() = %v; expected none", tags)
+	}
+	if actual := b.Translate(language.Parse("en-GB")[0], "hello"); actual != "hello" {
+		t.Errorf("Translate(en-GB, hello) = %q; expected %q", actual, "hello")
+	}
+}
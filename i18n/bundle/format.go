@@ -0,0 +1,274 @@
+package bundle
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/nicksnyder/go-i18n/i18n/language"
+	toml "github.com/pelletier/go-toml"
+	"gopkg.in/yaml.v2"
+)
+
+type format struct {
+	decode func([]byte) (interface{}, error)
+	encode func(interface{}) ([]byte, error)
+}
+
+var formats = struct {
+	m map[string]*format
+	sync.RWMutex
+}{m: make(map[string]*format)}
+
+func init() {
+	RegisterFormat(".json", decodeJSON, encodeJSON)
+	RegisterFormat(".yaml", decodeYAML, yaml.Marshal)
+	RegisterFormat(".toml", decodeTOML, encodeTOML)
+}
+
+// Catalog holds the translations of one language together with the
+// language they were translated from.
+//
+// Encoders of bilingual formats, such as gettext PO and XLIFF, accept a *Catalog
+// in addition to translations in the standard format.
+type Catalog struct {
+	Language       string
+	SourceLanguage string
+
+	// Translations holds translations in the standard format.
+	// A translation may have a "source" key that holds the
+	// corresponding translation of the source language.
+	Translations []interface{}
+}
+
+// toCatalog returns v if it is a *Catalog,
+// or a Catalog without languages if v holds translations in the standard format.
+// Bilingual formats cannot represent select translations, so they are an error.
+func toCatalog(v interface{}, format string) (*Catalog, error) {
+	var catalog *Catalog
+	switch v := v.(type) {
+	case *Catalog:
+		catalog = v
+	case []interface{}:
+		catalog = &Catalog{Translations: v}
+	default:
+		return nil, fmt.Errorf("invalid format for marshaling to %s", format)
+	}
+	for _, t := range catalog.Translations {
+		if data, ok := stringMap(t); ok && data["select"] != nil {
+			return nil, fmt.Errorf("%s does not support select translations like %q", format, translationID(data))
+		}
+	}
+	return catalog, nil
+}
+
+// RegisterFormat registers the functions that decode and encode translation files
+// whose names end with ext (e.g. ".json"). Registering an extension again replaces
+// the previous functions.
+//
+// decode must return either a slice that holds translations in the standard format
+// or a map that holds translations in the flat format (see README.md).
+// encode receives the same kind of data.
+//
+// Either function may be nil if the format can only be read or only be written.
+func RegisterFormat(ext string, decode func([]byte) (interface{}, error), encode func(interface{}) ([]byte, error)) {
+	formats.Lock()
+	formats.m[normalizeExt(ext)] = &format{decode, encode}
+	formats.Unlock()
+}
+
+// Marshal encodes v with the encoder that is registered for ext.
+func Marshal(ext string, v interface{}) ([]byte, error) {
+	f := lookupFormat(ext)
+	if f == nil || f.encode == nil {
+		return nil, fmt.Errorf("unsupported format %s", strings.TrimPrefix(ext, "."))
+	}
+	return f.encode(v)
+}
+
+// unmarshal decodes buf with the decoder that is registered for ext.
+func unmarshal(ext string, buf []byte) (interface{}, error) {
+	f := lookupFormat(ext)
+	if f == nil || f.decode == nil {
+		return nil, fmt.Errorf("unsupported file extension %v", ext)
+	}
+	return f.decode(buf)
+}
+
+// decodable reports whether a decoder is registered for ext.
+func decodable(ext string) bool {
+	f := lookupFormat(ext)
+	return f != nil && f.decode != nil
+}
+
+func lookupFormat(ext string) *format {
+	formats.RLock()
+	defer formats.RUnlock()
+	return formats.m[normalizeExt(ext)]
+}
+
+func normalizeExt(ext string) string {
+	ext = strings.ToLower(ext)
+	if !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+	return ext
+}
+
+func decodeJSON(buf []byte) (interface{}, error) {
+	var v interface{}
+	err := json.Unmarshal(buf, &v)
+	return v, err
+}
+
+func encodeJSON(v interface{}) ([]byte, error) {
+	return json.MarshalIndent(v, "", "  ")
+}
+
+func decodeYAML(buf []byte) (interface{}, error) {
+	var v interface{}
+	err := yaml.Unmarshal(buf, &v)
+	return v, err
+}
+
+// `github.com/pelletier/go-toml` lacks an Unmarshal function,
+// so TOML is decoded through a tree.
+func decodeTOML(buf []byte) (interface{}, error) {
+	tree, err := toml.LoadReader(bytes.NewReader(buf))
+	if err != nil {
+		return nil, err
+	}
+	return unquoteTOMLKeys(tree.ToMap()), nil
+}
+
+// encodeTOML only supports the flat format.
+func encodeTOML(v interface{}) ([]byte, error) {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid format for marshaling to TOML")
+	}
+	tree, err := toml.TreeFromMap(quoteTOMLKeys(m))
+	if err != nil {
+		return nil, err
+	}
+	s, err := tree.ToTomlString()
+	return []byte(s), err
+}
+
+// quoteTOMLKeys returns a copy of m whose keys that are not bare keys are quoted,
+// because go-toml writes keys as is. The key of a translation with a context contains "\x04" (see translation.Key).
+// Characters that cannot appear in quoted keys are written as \uXXXX escapes.
+func quoteTOMLKeys(m map[string]interface{}) map[string]interface{} {
+	quoted := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		if vm, ok := v.(map[string]interface{}); ok {
+			v = quoteTOMLKeys(vm)
+		}
+		quoted[quoteTOMLKey(k)] = v
+	}
+	return quoted
+}
+
+func quoteTOMLKey(k string) string {
+	bare := k != ""
+	for _, r := range k {
+		if !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' || r == '_' || r == '-') {
+			bare = false
+			break
+		}
+	}
+	if bare {
+		return k
+	}
+	var buf bytes.Buffer
+	buf.WriteByte('"')
+	for _, r := range k {
+		// go-toml does not unescape keys, and it ends a quoted key at any double quote.
+		if r < 0x20 || r == 0x7f || r == '"' || r == '\\' {
+			fmt.Fprintf(&buf, "\\u%04X", r)
+		} else {
+			buf.WriteRune(r)
+		}
+	}
+	buf.WriteByte('"')
+	return buf.String()
+}
+
+// unquoteTOMLKeys returns a copy of m whose keys are unescaped, because go-toml does not unescape quoted keys.
+func unquoteTOMLKeys(m map[string]interface{}) map[string]interface{} {
+	unquoted := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		if vm, ok := v.(map[string]interface{}); ok {
+			v = unquoteTOMLKeys(vm)
+		}
+		if strings.Contains(k, "\\") {
+			if u, err := strconv.Unquote(`"` + k + `"`); err == nil {
+				k = u
+			}
+		}
+		unquoted[k] = v
+	}
+	return unquoted
+}
+
+type translationForm struct {
+	plural language.Plural
+	text   string
+}
+
+// translationForms returns the forms of a single or plural translation value.
+// The forms of a plural translation are sorted in CLDR order.
+func translationForms(v interface{}) []translationForm {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Map {
+		return []translationForm{{"", translationText(v)}}
+	}
+	var forms []translationForm
+	for _, key := range rv.MapKeys() {
+		p, err := language.NewPlural(fmt.Sprint(key.Interface()))
+		if err != nil {
+			continue
+		}
+		forms = append(forms, translationForm{p, translationText(rv.MapIndex(key).Interface())})
+	}
+	sort.Sort(formsByPlural(forms))
+	return forms
+}
+
+type formsByPlural []translationForm
+
+func (a formsByPlural) Len() int      { return len(a) }
+func (a formsByPlural) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a formsByPlural) Less(i, j int) bool {
+	return pluralIndex(a[i].plural) < pluralIndex(a[j].plural)
+}
+
+var pluralOrder = []language.Plural{language.Zero, language.One, language.Two, language.Few, language.Many, language.Other}
+
+func pluralIndex(p language.Plural) int {
+	for i, o := range pluralOrder {
+		if p == o {
+			return i
+		}
+	}
+	return -1
+}
+
+// translationText returns the text of a template or string.
+func translationText(v interface{}) string {
+	if rv := reflect.ValueOf(v); !rv.IsValid() || (rv.Kind() == reflect.Ptr && rv.IsNil()) {
+		return ""
+	}
+	if tm, ok := v.(encoding.TextMarshaler); ok {
+		if buf, err := tm.MarshalText(); err == nil {
+			return string(buf)
+		}
+	}
+	return fmt.Sprint(v)
+}
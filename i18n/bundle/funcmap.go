@@ -0,0 +1,73 @@
+package bundle
+
+import (
+	"html/template"
+
+	"github.com/nicksnyder/go-i18n/i18n/language"
+)
+
+// FuncMap returns template functions that translate into lang, for text/template templates:
+//
+//	{{T "program_greeting"}}                      translates like a TranslateFunc of lang
+//	{{T "person_greeting" .}}                     with template data
+//	{{Tn "your_unread_email_count" .Count}}       with a count, for plural translations
+//	{{Tn "person_unread_email_count" .Count .}}   with a count and template data
+//	{{Tlang "fr-FR" "program_greeting"}}          into another language, a language tag or an Accept-Language header
+//
+// Like a TranslateFunc, the functions fall back to the fallbacks and parent languages of lang
+// and to the default language, and lang can be nil.
+// The languages are resolved each time a function is called,
+// so the functions see the translations, fallbacks and default language that are set later.
+//
+// The functions return the translations as plain text, including the translations that are marked as HTML.
+// Use HTMLFuncMap for html/template templates.
+//
+// The map can be passed to the Funcs method of text/template templates.
+func (b *Bundle) FuncMap(lang *language.Language) map[string]interface{} {
+	return b.funcMap(lang, func(lang *language.Language, langs []*language.Language, translationID string, args ...interface{}) interface{} {
+		return b.translate(lang, langs, translationID, args...)
+	})
+}
+
+// HTMLFuncMap is similar to FuncMap except it returns template functions for html/template templates.
+//
+// html/template escapes translations like other strings, unless a translation is marked as HTML
+// (see translation.Metadata): then its markup is written as is, and its template is executed
+// with html/template, which escapes the template data instead, except values of type template.HTML.
+//
+// The map can be passed to the Funcs method of html/template templates.
+func (b *Bundle) HTMLFuncMap(lang *language.Language) map[string]interface{} {
+	return b.funcMap(lang, b.translateHTML)
+}
+
+// funcMap returns the template functions of FuncMap, which translate with translate.
+func (b *Bundle) funcMap(lang *language.Language, translate func(lang *language.Language, langs []*language.Language, translationID string, args ...interface{}) interface{}) map[string]interface{} {
+	var prefs []*language.Language
+	if lang != nil {
+		prefs = append(prefs, lang)
+	}
+	return map[string]interface{}{
+		"T": func(translationID string, args ...interface{}) interface{} {
+			return translate(lang, b.fallbackLanguages(prefs), translationID, args...)
+		},
+		"Tn": func(translationID string, count interface{}, args ...interface{}) interface{} {
+			return translate(lang, b.fallbackLanguages(prefs), translationID, append([]interface{}{count}, args...)...)
+		},
+		"Tlang": func(tag, translationID string, args ...interface{}) interface{} {
+			lang, langs := b.preferredLanguages(tag)
+			return translate(lang, langs, translationID, args...)
+		},
+	}
+}
+
+// translateHTML is like translate, except it returns the translations that are marked as HTML as template.HTML.
+func (b *Bundle) translateHTML(lang *language.Language, langs []*language.Language, translationID string, args ...interface{}) interface{} {
+	s, html, err := b.localizeHTML(lang, langs, true, translationID, args...)
+	if err != nil {
+		return b.failed(lang, translationID, err)
+	}
+	if html {
+		return template.HTML(s)
+	}
+	return s
+}
@@ -0,0 +1,142 @@
+package bundle
+
+import (
+	"bytes"
+	htmltemplate "html/template"
+	"testing"
+	texttemplate "text/template"
+
+	"github.com/nicksnyder/go-i18n/i18n/language"
+)
+
+func TestFuncMap(t *testing.T) {
+	b := New()
+	for filename, content := range map[string]string{
+		"en-US.json": `{
+			"greeting": {"other": "Hello <b>{{.Person}}</b>"},
+			"bold_greeting": {"other": "Hello <b>{{.Person}}</b>", "html": true},
+			"bold_user": {"other": "Hello <b>{{.User.Name}}</b> from <i>{{.Map.City}}</i>", "html": true},
+			"bold_icu": {"other": "Hello <b>{Person}</b>", "syntax": "icu", "html": true},
+			"files": {"one": "{{.Count}} <i>file</i>", "other": "{{.Count}} <i>files</i>", "html": true},
+			"person_files": {"one": "{{.Person}} has {{.Count}} file", "other": "{{.Person}} has {{.Count}} files"}
+		}`,
+		"fr-FR.json": `{"greeting": {"other": "Bonjour <b>{{.Person}}</b>"}}`,
+	} {
+		if err := b.ParseTranslationFileBytes(filename, []byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	type name string
+	data := map[string]interface{}{
+		"Person": "<script>Bob</script>",
+		"Safe":   htmltemplate.HTML("<em>Bob</em>"),
+		"Named":  name("<script>Bob</script>"),
+		"User":   struct{ Name name }{"<script>Bob</script>"},
+		"Map":    map[string]interface{}{"City": "<script>Zurich</script>"},
+		"Count":  2,
+	}
+	tests := []struct {
+		template string
+		html     string
+		text     string
+	}{
+		{
+			`{{T "greeting" .}}`,
+			`Hello &lt;b&gt;&lt;script&gt;Bob&lt;/script&gt;&lt;/b&gt;`,
+			`Hello <b><script>Bob</script></b>`,
+		},
+		{
+			`{{T "bold_greeting" .}}`,
+			`Hello <b>&lt;script&gt;Bob&lt;/script&gt;</b>`,
+			`Hello <b><script>Bob</script></b>`,
+		},
+		{
+			`{{T "bold_greeting" (dict "Person" .Safe)}}`,
+			`Hello <b><em>Bob</em></b>`,
+			`Hello <b><em>Bob</em></b>`,
+		},
+		{
+			`{{T "bold_greeting" (dict "Person" .Named)}}`,
+			`Hello <b>&lt;script&gt;Bob&lt;/script&gt;</b>`,
+			`Hello <b><script>Bob</script></b>`,
+		},
+		{
+			`{{T "bold_user" .}}`,
+			`Hello <b>&lt;script&gt;Bob&lt;/script&gt;</b> from <i>&lt;script&gt;Zurich&lt;/script&gt;</i>`,
+			`Hello <b><script>Bob</script></b> from <i><script>Zurich</script></i>`,
+		},
+		{
+			`{{T "bold_icu" .}} {{T "bold_icu" (dict "Person" .Named)}} {{T "bold_icu" (dict "Person" .Safe)}}`,
+			`Hello <b>&lt;script&gt;Bob&lt;/script&gt;</b> Hello <b>&lt;script&gt;Bob&lt;/script&gt;</b> Hello <b><em>Bob</em></b>`,
+			`Hello <b><script>Bob</script></b> Hello <b><script>Bob</script></b> Hello <b><em>Bob</em></b>`,
+		},
+		{
+			`{{Tn "files" 1}} {{Tn "files" .Count}}`,
+			`1 <i>file</i> 2 <i>files</i>`,
+			`1 <i>file</i> 2 <i>files</i>`,
+		},
+		{
+			`{{Tn "person_files" 1 .}}`,
+			`&lt;script&gt;Bob&lt;/script&gt; has 1 file`,
+			`<script>Bob</script> has 1 file`,
+		},
+		{
+			`{{Tlang "fr-FR" "greeting" (dict "Person" "Bob")}} {{Tlang "ja" "greeting"}}`,
+			`Bonjour &lt;b&gt;Bob&lt;/b&gt; greeting`,
+			`Bonjour <b>Bob</b> greeting`,
+		},
+		{
+			`{{T "missing"}}`,
+			`missing`,
+			`missing`,
+		},
+	}
+	dict := func(k string, v interface{}) map[string]interface{} {
+		return map[string]interface{}{k: v}
+	}
+	en := language.MustParse("en-US")[0]
+	htmlFuncs := b.HTMLFuncMap(en)
+	htmlFuncs["dict"] = dict
+	funcs := b.FuncMap(en)
+	funcs["dict"] = dict
+	for _, test := range tests {
+		var buf bytes.Buffer
+		if err := htmltemplate.Must(htmltemplate.New("").Funcs(htmlFuncs).Parse(test.template)).Execute(&buf, data); err != nil {
+			t.Error(err)
+		} else if buf.String() != test.html {
+			t.Errorf("html/template %s = %s; expected %s", test.template, buf.String(), test.html)
+		}
+		buf.Reset()
+		if err := texttemplate.Must(texttemplate.New("").Funcs(funcs).Parse(test.template)).Execute(&buf, data); err != nil {
+			t.Error(err)
+		} else if buf.String() != test.text {
+			t.Errorf("text/template %s = %s; expected %s", test.template, buf.String(), test.text)
+		}
+	}
+}
+
+func TestFuncMapLanguages(t *testing.T) {
+	b := New()
+	funcs := b.FuncMap(language.MustParse("fr-CA")[0])
+	tmpl := texttemplate.Must(texttemplate.New("").Funcs(funcs).Parse(`{{T "hello"}} {{Tlang "de" "hello"}}`))
+
+	// The translations and the default language are set after FuncMap is called.
+	for filename, content := range map[string]string{
+		"en-US.json": `[{"id": "hello", "translation": "Hello"}]`,
+		"fr.json":    `[{"id": "bye", "translation": "Au revoir"}]`,
+	} {
+		if err := b.ParseTranslationFileBytes(filename, []byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := b.SetDefaultLanguage("en-US"); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "Hello Hello"; buf.String() != expected {
+		t.Errorf("Execute() = %q; expected %q", buf.String(), expected)
+	}
+}
@@ -0,0 +1,380 @@
+package bundle
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nicksnyder/go-i18n/i18n/language"
+)
+
+func init() {
+	RegisterFormat(".po", decodePO, encodePO)
+	RegisterFormat(".pot", decodePO, encodePO)
+}
+
+type poEntry struct {
+	context  string
+	id       string
+	idPlural string
+	strs     map[int]*string
+
+	// fuzzy is true if the entry has the fuzzy flag, which means that its translation needs review.
+	fuzzy bool
+}
+
+// data returns the translation of e in the standard format.
+// nplurals is the number of plural forms of the file (see poPluralCount), or 0 if it is unknown.
+func (e *poEntry) data(nplurals int) map[string]interface{} {
+	data := map[string]interface{}{"id": e.id}
+	if e.context != "" {
+		data["context"] = e.context
+	}
+	if e.fuzzy {
+		empty := ""
+		for i := range e.strs {
+			e.strs[i] = &empty
+		}
+	}
+	if e.idPlural == "" && len(e.strs) <= 1 {
+		translation := ""
+		if s := e.strs[0]; s != nil {
+			translation = *s
+		}
+		data["translation"] = translation
+		return data
+	}
+	var indexes []int
+	for i := range e.strs {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+	forms := make([]interface{}, 0, len(indexes))
+	for _, i := range indexes {
+		for len(forms) < i {
+			forms = append(forms, "")
+		}
+		forms = append(forms, *e.strs[i])
+	}
+	data["translation"] = forms
+	if nplurals > 0 {
+		data["nplurals"] = nplurals
+	}
+	return data
+}
+
+// decodePO decodes a gettext PO or POT file into translations in the standard format.
+//
+// The msgctxt of a translation is its "context".
+// The msgstr[n] forms of plural translations are returned as a list,
+// which is mapped onto the plural categories of the file's language.
+// If the header has a Plural-Forms, its nplurals is returned with each list as "nplurals",
+// so a file whose plural forms are not those of its language is reported.
+// The translations of entries with the fuzzy flag are empty, so they are untranslated until they are reviewed.
+func decodePO(buf []byte) (interface{}, error) {
+	var header *poEntry
+	var entries []*poEntry
+	var entry *poEntry
+	var field *string
+	inMsgstr := false
+	// fuzzy is true if the flags of the next entry have the fuzzy flag.
+	fuzzy := false
+	newEntry := func(context string) {
+		entry = &poEntry{context: context, strs: make(map[int]*string), fuzzy: fuzzy}
+		fuzzy = false
+	}
+	flush := func() {
+		// The entry with an empty msgid is the header.
+		if entry != nil {
+			if entry.id == "" && entry.context == "" {
+				header = entry
+			} else {
+				entries = append(entries, entry)
+			}
+		}
+		entry, field, inMsgstr = nil, nil, false
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(buf))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+			flush()
+			continue
+		case strings.HasPrefix(line, "#,"):
+			for _, flag := range strings.Split(line[2:], ",") {
+				if strings.TrimSpace(flag) == "fuzzy" {
+					fuzzy = true
+				}
+			}
+			continue
+		case strings.HasPrefix(line, "#"):
+			// Other comments, including obsolete entries (#~), are ignored.
+			continue
+		case strings.HasPrefix(line, `"`):
+			if field == nil {
+				return nil, fmt.Errorf("line %d: unexpected string", n)
+			}
+			s, err := poUnquote(line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %s", n, err)
+			}
+			*field += s
+			continue
+		}
+
+		i := strings.IndexAny(line, " \t")
+		if i == -1 {
+			return nil, fmt.Errorf("line %d: missing string after %q", n, line)
+		}
+		keyword := line[:i]
+		s, err := poUnquote(strings.TrimSpace(line[i:]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", n, err)
+		}
+		switch {
+		case keyword == "msgctxt":
+			flush()
+			newEntry(s)
+			field = &entry.context
+		case keyword == "msgid":
+			if entry == nil || inMsgstr {
+				flush()
+				newEntry("")
+			}
+			entry.id = s
+			field = &entry.id
+		case keyword == "msgid_plural":
+			if entry == nil {
+				return nil, fmt.Errorf("line %d: msgid_plural without msgid", n)
+			}
+			entry.idPlural = s
+			field = &entry.idPlural
+		case strings.HasPrefix(keyword, "msgstr"):
+			if entry == nil {
+				return nil, fmt.Errorf("line %d: msgstr without msgid", n)
+			}
+			index, err := poIndex(keyword)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %s", n, err)
+			}
+			field = &s
+			entry.strs[index] = field
+			inMsgstr = true
+		default:
+			return nil, fmt.Errorf("line %d: unknown keyword %q", n, keyword)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+
+	nplurals := 0
+	if header != nil && header.strs[0] != nil {
+		n, err := poPluralCount(*header.strs[0])
+		if err != nil {
+			return nil, err
+		}
+		nplurals = n
+	}
+	translations := make([]interface{}, len(entries))
+	for i, e := range entries {
+		translations[i] = e.data(nplurals)
+	}
+	return translations, nil
+}
+
+// poPluralCount returns the nplurals of the Plural-Forms of header, the msgstr of the header entry of a PO file,
+// or 0 if header has no Plural-Forms.
+func poPluralCount(header string) (int, error) {
+	for _, line := range strings.Split(header, "\n") {
+		i := strings.Index(line, ":")
+		if i == -1 || !strings.EqualFold(strings.TrimSpace(line[:i]), "Plural-Forms") {
+			continue
+		}
+		value := strings.TrimSpace(line[i+1:])
+		for _, field := range strings.Split(value, ";") {
+			j := strings.Index(field, "=")
+			if j == -1 || strings.TrimSpace(field[:j]) != "nplurals" {
+				continue
+			}
+			n, err := strconv.Atoi(strings.TrimSpace(field[j+1:]))
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("invalid nplurals in Plural-Forms %q", value)
+			}
+			return n, nil
+		}
+		return 0, fmt.Errorf("missing nplurals in Plural-Forms %q", value)
+	}
+	return 0, nil
+}
+
+func poIndex(keyword string) (int, error) {
+	if keyword == "msgstr" {
+		return 0, nil
+	}
+	if !strings.HasPrefix(keyword, "msgstr[") || !strings.HasSuffix(keyword, "]") {
+		return 0, fmt.Errorf("unknown keyword %q", keyword)
+	}
+	index, err := strconv.Atoi(keyword[len("msgstr[") : len(keyword)-1])
+	if err != nil || index < 0 {
+		return 0, fmt.Errorf("invalid plural index in %q", keyword)
+	}
+	return index, nil
+}
+
+func poUnquote(s string) (string, error) {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return "", fmt.Errorf("invalid string %s", s)
+	}
+	var buf bytes.Buffer
+	s = s[1 : len(s)-1]
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '\\' {
+			buf.WriteByte(c)
+			continue
+		}
+		i++
+		if i == len(s) {
+			return "", fmt.Errorf("invalid escape at end of string")
+		}
+		switch s[i] {
+		case 'n':
+			buf.WriteByte('\n')
+		case 't':
+			buf.WriteByte('\t')
+		case 'r':
+			buf.WriteByte('\r')
+		default:
+			buf.WriteByte(s[i])
+		}
+	}
+	return buf.String(), nil
+}
+
+// encodePO encodes translations in the standard format, or a *Catalog, as a gettext PO file.
+//
+// The forms of plural translations are written as msgstr[n] in CLDR order.
+// If the language of a *Catalog is known, the header has its Language in the form of gettext (e.g. de_DE)
+// and its Plural-Forms (see language.PluralSpec.GettextPlural),
+// and there is a msgstr[n] for each of its plural categories.
+// Gettext has no ordinal plural forms, so ordinal translations are an error.
+// The descriptions of translations and the source translations of a *Catalog
+// are written as extracted comments (#.) so translators can see what they are translating.
+func encodePO(v interface{}) ([]byte, error) {
+	catalog, err := toCatalog(v, "PO")
+	if err != nil {
+		return nil, err
+	}
+
+	var plurals []language.Plural
+	var buf bytes.Buffer
+	buf.WriteString("msgid \"\"\nmsgstr \"\"\n\"Content-Type: text/plain; charset=UTF-8\\n\"\n")
+	if catalog.Language != "" {
+		fmt.Fprintf(&buf, "\"Language: %s\\n\"\n", gettextLanguage(catalog.Language))
+		if spec := language.GetPluralSpec(catalog.Language); spec != nil {
+			plurals = spec.SortedPlurals()
+			fmt.Fprintf(&buf, "\"Plural-Forms: nplurals=%d; plural=(%s);\\n\"\n", len(plurals), spec.GettextPlural)
+		}
+	}
+
+	for i, t := range catalog.Translations {
+		data, ok := stringMap(t)
+		if !ok {
+			return nil, fmt.Errorf("translation #%d is %T; expected an object", i, t)
+		}
+		id, _ := data["id"].(string)
+		if data["ordinal"] == true {
+			// The msgstr[n] forms of gettext are the cardinal plural forms of the language (see Plural-Forms).
+			return nil, fmt.Errorf("PO does not support ordinal translations like %q", id)
+		}
+		buf.WriteString("\n")
+		if description, ok := data["description"].(string); ok && description != "" {
+			for _, line := range strings.Split(description, "\n") {
+				buf.WriteString("#. " + line + "\n")
+			}
+		}
+		if source, ok := data["source"]; ok {
+			for _, form := range translationForms(source) {
+				for _, line := range strings.Split(form.text, "\n") {
+					buf.WriteString("#.")
+					if form.plural != "" {
+						buf.WriteString(" " + string(form.plural) + ":")
+					}
+					if line != "" {
+						buf.WriteString(" " + line)
+					}
+					buf.WriteString("\n")
+				}
+			}
+		}
+		if context, ok := data["context"].(string); ok && context != "" {
+			writePOString(&buf, "msgctxt", context)
+		}
+		writePOString(&buf, "msgid", id)
+		forms := translationForms(data["translation"])
+		if len(forms) == 1 && forms[0].plural == "" {
+			writePOString(&buf, "msgstr", forms[0].text)
+			continue
+		}
+		writePOString(&buf, "msgid_plural", id)
+		if plurals != nil {
+			// msgstr[n] is the form of the nth plural category of the language, as in the Plural-Forms header.
+			texts := make(map[language.Plural]string, len(forms))
+			for _, form := range forms {
+				texts[form.plural] = form.text
+			}
+			for n, p := range plurals {
+				writePOString(&buf, fmt.Sprintf("msgstr[%d]", n), texts[p])
+			}
+			continue
+		}
+		for n, form := range forms {
+			writePOString(&buf, fmt.Sprintf("msgstr[%d]", n), form.text)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// gettextLanguage returns tag in the form of the Language header of gettext,
+// with an upper case region and a title case script (e.g. de_DE for de-de and zh_Hant_TW for zh-hant-tw).
+func gettextLanguage(tag string) string {
+	parts := strings.Split(language.NormalizeTag(tag), "-")
+	for i, part := range parts {
+		switch {
+		case i == 0:
+		case len(part) == 4:
+			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+		case len(part) == 2:
+			parts[i] = strings.ToUpper(part)
+		}
+	}
+	return strings.Join(parts, "_")
+}
+
+func writePOString(buf *bytes.Buffer, keyword, s string) {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) <= 1 {
+		fmt.Fprintf(buf, "%s %s\n", keyword, poQuote(s))
+		return
+	}
+	fmt.Fprintf(buf, "%s \"\"\n", keyword)
+	for _, line := range lines {
+		fmt.Fprintf(buf, "%s\n", poQuote(line))
+	}
+}
+
+var poEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`, "\r", `\r`)
+
+func poQuote(s string) string {
+	return `"` + poEscaper.Replace(s) + `"`
+}
@@ -0,0 +1,211 @@
+package bundle
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nicksnyder/go-i18n/i18n/language"
+	"github.com/nicksnyder/go-i18n/i18n/translation"
+)
+
+// Watcher reloads the translation files of a bundle when they change (see Bundle.Watch).
+type Watcher struct {
+	bundle   *Bundle
+	patterns []string
+	onError  func(error)
+
+	// The files that were loaded by their filename.
+	files map[string]*watchedFile
+
+	// mu serializes reloads.
+	mu sync.Mutex
+
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// watchedFile is the last good state of a translation file.
+// lang is nil if the file has never loaded.
+type watchedFile struct {
+	lang         *language.Language
+	translations []translation.Translation
+	modTime      time.Time
+	size         int64
+}
+
+// Watch loads the translation files that match patterns (see filepath.Glob) into b,
+// then checks them for changes every interval until the watcher is closed, so translations
+// can be edited without restarting the program. The language of each file is parsed from its filename.
+//
+// When files change, are added or are removed, the translations of their languages are replaced
+// with the translations of all files of the language that match patterns (see SetTranslations),
+// so the translations that were deleted from a file are dropped. The watcher owns those languages:
+// their translations that were added in other ways are dropped as well.
+//
+// A file that fails to load keeps its last good translations, and onError is called with a *FileError.
+// onError may be nil.
+//
+// Watch returns an error if a pattern is malformed or if a file fails to load initially,
+// and then it does not watch and adds none of the files to b.
+func (b *Bundle) Watch(interval time.Duration, onError func(error), patterns ...string) (*Watcher, error) {
+	for _, pattern := range patterns {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, err
+		}
+	}
+	w := &Watcher{
+		bundle:   b,
+		patterns: patterns,
+		onError:  onError,
+		files:    make(map[string]*watchedFile),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	if err := w.reload(true); err != nil {
+		return nil, err
+	}
+	go w.run(interval)
+	return w, nil
+}
+
+func (w *Watcher) run(interval time.Duration) {
+	defer close(w.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			if errs, ok := w.Reload().(FileErrors); ok && w.onError != nil {
+				for _, err := range errs {
+					w.onError(err)
+				}
+			}
+		}
+	}
+}
+
+// Reload checks the files of the watcher for changes now, without waiting for the next interval.
+// It returns a FileErrors that lists every file that failed to load; onError is not called.
+func (w *Watcher) Reload() error {
+	return w.reload(false)
+}
+
+// reload loads the files that changed. If initial is true, then no file is added to the bundle
+// unless every file loads.
+func (w *Watcher) reload(initial bool) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var errs FileErrors
+	filenames := make(map[string]bool)
+	for _, pattern := range w.patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return err
+		}
+		for _, filename := range matches {
+			filenames[filename] = true
+		}
+	}
+
+	// The tags of the languages whose files changed.
+	changed := make(map[string]*language.Language)
+	for filename, f := range w.files {
+		if !filenames[filename] {
+			delete(w.files, filename)
+			if f.lang != nil {
+				changed[f.lang.Tag] = f.lang
+			}
+		}
+	}
+	for filename := range filenames {
+		info, err := os.Stat(filename)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				errs = append(errs, &FileError{filename, err})
+			}
+			continue
+		}
+		if info.IsDir() {
+			continue
+		}
+		f := w.files[filename]
+		if f != nil && f.modTime.Equal(info.ModTime()) && f.size == info.Size() {
+			continue
+		}
+		lang, translations, err := loadTranslationFile(filename)
+		if err != nil {
+			errs = append(errs, &FileError{filename, err})
+			// Keep the last good translations, and report the error again only when the file changes again.
+			if f == nil {
+				f = &watchedFile{}
+				w.files[filename] = f
+			}
+			f.modTime, f.size = info.ModTime(), info.Size()
+			continue
+		}
+		if f != nil && f.lang != nil && f.lang.Tag != lang.Tag {
+			changed[f.lang.Tag] = f.lang
+		}
+		w.files[filename] = &watchedFile{lang, translations, info.ModTime(), info.Size()}
+		changed[lang.Tag] = lang
+	}
+
+	if initial && len(errs) > 0 {
+		sort.Sort(byFilename(errs))
+		return errs
+	}
+	for tag, lang := range changed {
+		w.bundle.SetTranslations(lang, w.languageTranslations(tag)...)
+	}
+	if len(errs) > 0 {
+		sort.Sort(byFilename(errs))
+		return errs
+	}
+	return nil
+}
+
+// languageTranslations returns the translations of the files of the language tag, in filename order.
+func (w *Watcher) languageTranslations(tag string) []translation.Translation {
+	var filenames []string
+	for filename, f := range w.files {
+		if f.lang != nil && f.lang.Tag == tag {
+			filenames = append(filenames, filename)
+		}
+	}
+	sort.Strings(filenames)
+	var translations []translation.Translation
+	for _, filename := range filenames {
+		translations = append(translations, w.files[filename].translations...)
+	}
+	return translations
+}
+
+// Close stops watching the files. The translations that were loaded stay in the bundle.
+func (w *Watcher) Close() error {
+	w.closeOnce.Do(func() {
+		close(w.stop)
+	})
+	<-w.done
+	return nil
+}
+
+func loadTranslationFile(filename string) (*language.Language, []translation.Translation, error) {
+	buf, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ParseTranslationFile(filename, buf)
+}
+
+type byFilename FileErrors
+
+func (fe byFilename) Len() int           { return len(fe) }
+func (fe byFilename) Swap(i, j int)      { fe[i], fe[j] = fe[j], fe[i] }
+func (fe byFilename) Less(i, j int) bool { return fe[i].Filename < fe[j].Filename }
@@ -0,0 +1,172 @@
+package bundle
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-i18n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	writeFile := func(name, content string) {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile("en-US.json", `{"hello": {"other": "Hello"}, "bye": {"other": "Bye"}}`)
+	writeFile("fr-FR.json", `{"hello": {"other": "Bonjour"}}`)
+
+	b := New()
+	var errs []error
+	w, err := b.Watch(time.Hour, func(err error) { errs = append(errs, err) }, filepath.Join(dir, "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	check := func(step, tag, translationID, expected string) {
+		if actual := b.MustTfunc(tag)(translationID); actual != expected {
+			t.Errorf("%s: Tfunc(%s)(%s) = %q; expected %q", step, tag, translationID, actual, expected)
+		}
+	}
+	check("load", "en-US", "hello", "Hello")
+	check("load", "fr-FR", "hello", "Bonjour")
+
+	// Deleted translations are dropped.
+	writeFile("en-US.json", `{"hello": {"other": "Hello there"}}`)
+	if err := w.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	check("change", "en-US", "hello", "Hello there")
+	check("change", "en-US", "bye", "bye")
+
+	// A file that fails to load keeps its last good translations.
+	writeFile("en-US.json", `{"hello": `)
+	if err := w.Reload(); err == nil {
+		t.Error("expected an error for a malformed file")
+	}
+	check("error", "en-US", "hello", "Hello there")
+	if err := w.Reload(); err != nil {
+		t.Errorf("expected the error of an unchanged file to be reported once; got %s", err)
+	}
+
+	// Files of a language are merged; added and removed files are picked up.
+	writeFile("en-US.json", `{"hello": {"other": "Hi"}}`)
+	writeFile("en-US.extra.json", `{"bye": {"other": "See you"}}`)
+	if err := os.Remove(filepath.Join(dir, "fr-FR.json")); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	check("add and remove", "en-US", "hello", "Hi")
+	check("add and remove", "en-US", "bye", "See you")
+	if tags := b.LanguageTags(); !reflect.DeepEqual(tags, []string{"en-us"}) {
+		t.Errorf("LanguageTags() = %v; expected [en-us]", tags)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 0 {
+		t.Errorf("onError was called by Reload: %v", errs)
+	}
+}
+
+func TestWatchDuplicateTranslations(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-i18n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	writeFile := func(name, content string) {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile("en-US.a.json", `{"hello": {"other": "From A"}}`)
+	writeFile("en-US.b.json", `{"hello": {"other": "From B"}}`)
+
+	b := New()
+	w, err := b.Watch(time.Hour, nil, filepath.Join(dir, "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	if actual := b.MustTfunc("en-US")("hello"); actual != "From B" {
+		t.Errorf("Tfunc(en-US)(hello) = %q; expected %q", actual, "From B")
+	}
+
+	// The translation of a.json was not changed by merging the translation of b.json into it.
+	writeFile("en-US.b.json", `{"bye": {"other": "Bye"}}`)
+	if err := w.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if actual := b.MustTfunc("en-US")("hello"); actual != "From A" {
+		t.Errorf("Tfunc(en-US)(hello) = %q; expected %q", actual, "From A")
+	}
+}
+
+func TestWatchInterval(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-i18n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "en-US.json")
+	if err := ioutil.WriteFile(filename, []byte(`{"hello": {"other": "Hello"}}`), 0666); err != nil {
+		t.Fatal(err)
+	}
+	b := New()
+	errs := make(chan error, 1)
+	w, err := b.Watch(10*time.Millisecond, func(err error) { errs <- err }, filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	if err := ioutil.WriteFile(filename, []byte(`not json`), 0666); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-errs:
+		if fe, ok := err.(*FileError); !ok || fe.Filename != filename {
+			t.Errorf("onError(%v); expected a *FileError of %s", err, filename)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("onError was not called")
+	}
+	if actual := b.MustTfunc("en-US")("hello"); actual != "Hello" {
+		t.Errorf("Tfunc(en-US)(hello) = %q; expected %q", actual, "Hello")
+	}
+}
+
+func TestWatchInitialError(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-i18n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(filepath.Join(dir, "en-US.json"), []byte(`not json`), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "fr-FR.json"), []byte(`{"hello": {"other": "Bonjour"}}`), 0666); err != nil {
+		t.Fatal(err)
+	}
+	b := New()
+	if _, err := b.Watch(time.Hour, nil, filepath.Join(dir, "*.json")); err == nil {
+		t.Error("expected an error")
+	}
+	if tags := b.LanguageTags(); len(tags) != 0 {
+		t.Errorf("LanguageTags() = %v; expected the files that loaded not to be added", tags)
+	}
+	if _, err := New().Watch(time.Hour, nil, "["); err == nil {
+		t.Error("expected an error for a malformed pattern")
+	}
+}
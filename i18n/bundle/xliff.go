@@ -0,0 +1,490 @@
+package bundle
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nicksnyder/go-i18n/i18n/language"
+)
+
+// XLIFF files are read in the version declared by their xliff element, whatever their extension.
+// They have no encoder because the extension does not tell the version; see MarshalXLIFF.
+func init() {
+	RegisterFormat(".xliff", decodeXLIFF, nil)
+	RegisterFormat(".xlf", decodeXLIFF, nil)
+}
+
+// The XLIFF versions that MarshalXLIFF writes.
+const (
+	XLIFF12 = "1.2"
+	XLIFF20 = "2.0"
+)
+
+// MarshalXLIFF encodes translations in the standard format, or a *Catalog, as an XLIFF file of version.
+func MarshalXLIFF(version string, v interface{}) ([]byte, error) {
+	switch version {
+	case XLIFF12:
+		return encodeXLIFF12(v)
+	case XLIFF20:
+		return encodeXLIFF20(v)
+	}
+	return nil, fmt.Errorf("unsupported XLIFF version %q", version)
+}
+
+const (
+	xliff12Namespace = "urn:oasis:names:tc:xliff:document:1.2"
+	xliff20Namespace = "urn:oasis:names:tc:xliff:document:2.0"
+
+	// xliff12PluralGroup is the restype of XLIFF 1.2 groups that hold the forms of a plural translation.
+	xliff12PluralGroup = "x-gettext-plurals"
+
+	// xliff20PluralGroup is the type of XLIFF 2.0 groups that hold the forms of a plural translation.
+	xliff20PluralGroup = "i18n:plural"
+
+	// xliff12OrdinalGroup is the restype of XLIFF 1.2 groups that hold the forms of an ordinal translation.
+	xliff12OrdinalGroup = "x-i18n-ordinals"
+
+	// xliff20OrdinalGroup is the type of XLIFF 2.0 groups that hold the forms of an ordinal translation.
+	xliff20OrdinalGroup = "i18n:ordinal"
+
+	// xliff12ContextType is the context-type of XLIFF 1.2 contexts that hold the context of a translation.
+	xliff12ContextType = "x-gettext-msgctxt"
+
+	// xliff20ContextCategory is the category of XLIFF 2.0 notes that hold the context of a translation.
+	xliff20ContextCategory = "context"
+
+	// xliff20DescriptionCategory is the category of XLIFF 2.0 notes that hold the description of a translation.
+	xliff20DescriptionCategory = "description"
+
+	// xliff20MaxLengthCategory is the category of XLIFF 2.0 notes that hold the maximum length of a translation.
+	xliff20MaxLengthCategory = "maxLength"
+
+	// xliffCharSizeUnit is the XLIFF 1.2 size-unit of a maxwidth in characters.
+	xliffCharSizeUnit = "char"
+)
+
+type xliffDocument struct {
+	XMLName xml.Name    `xml:"xliff"`
+	Xmlns   string      `xml:"xmlns,attr,omitempty"`
+	Version string      `xml:"version,attr"`
+	SrcLang *string     `xml:"srcLang,attr"` // required in XLIFF 2.0, not in XLIFF 1.2
+	TrgLang string      `xml:"trgLang,attr,omitempty"`
+	Files   []xliffFile `xml:"file"`
+}
+
+type xliffFile struct {
+	ID             string         `xml:"id,attr,omitempty"`
+	Original       string         `xml:"original,attr,omitempty"`
+	SourceLanguage string         `xml:"source-language,attr,omitempty"`
+	TargetLanguage string         `xml:"target-language,attr,omitempty"`
+	Datatype       string         `xml:"datatype,attr,omitempty"`
+	Body           *xliffElement  `xml:"body"`
+	Elements       []xliffElement `xml:",any"`
+}
+
+// xliffElement is a group, trans-unit (1.2), unit (2.0) or segment (2.0) element.
+type xliffElement struct {
+	XMLName  xml.Name
+	ID       string             `xml:"id,attr,omitempty"`
+	Name     string             `xml:"name,attr,omitempty"`
+	Resname  string             `xml:"resname,attr,omitempty"`
+	Restype  string             `xml:"restype,attr,omitempty"`
+	Type     string             `xml:"type,attr,omitempty"`
+	MaxWidth int                `xml:"maxwidth,attr,omitempty"`
+	SizeUnit string             `xml:"size-unit,attr,omitempty"`
+	Source   *string            `xml:"source"`
+	Target   *string            `xml:"target"`
+	Contexts *xliffContextGroup `xml:"context-group"`
+	Note     []xliffNote        `xml:"note"`
+	Notes    *xliffNotes        `xml:"notes"`
+	Elements []xliffElement     `xml:",any"`
+}
+
+type xliffContextGroup struct {
+	Contexts []xliffContext `xml:"context"`
+}
+
+type xliffNotes struct {
+	Notes []xliffNote `xml:"note"`
+}
+
+// xliffContext is a context of an XLIFF 1.2 context-group.
+type xliffContext struct {
+	Type  string `xml:"context-type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// xliffNote is a note of an XLIFF 1.2 trans-unit or group, or of the notes of an XLIFF 2.0 unit or group.
+type xliffNote struct {
+	Category string `xml:"category,attr,omitempty"`
+	Value    string `xml:",chardata"`
+}
+
+// decodeXLIFF decodes an XLIFF 1.2 or 2.0 file into translations in the standard format.
+//
+// The translations are taken from the target elements; units without a target are untranslated.
+// Groups of plural forms, as written by encodeXLIFF12 and encodeXLIFF20, become plural translations,
+// and groups of ordinal forms become ordinal translations.
+func decodeXLIFF(buf []byte) (interface{}, error) {
+	var doc xliffDocument
+	if err := xml.Unmarshal(buf, &doc); err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(doc.Version, "1.") && !strings.HasPrefix(doc.Version, "2.") {
+		return nil, fmt.Errorf("unsupported XLIFF version %q", doc.Version)
+	}
+	translations := []interface{}{}
+	for _, file := range doc.Files {
+		elements := file.Elements
+		if file.Body != nil {
+			elements = file.Body.Elements
+		}
+		translations = appendXLIFFTranslations(translations, elements)
+	}
+	return translations, nil
+}
+
+func appendXLIFFTranslations(translations []interface{}, elements []xliffElement) []interface{} {
+	for _, e := range elements {
+		switch e.XMLName.Local {
+		case "trans-unit", "unit":
+			translations = append(translations, e.translationData(e.target()))
+		case "group":
+			ordinal := e.Restype == xliff12OrdinalGroup || e.Type == xliff20OrdinalGroup
+			if !ordinal && e.Restype != xliff12PluralGroup && e.Type != xliff20PluralGroup {
+				translations = appendXLIFFTranslations(translations, e.Elements)
+				continue
+			}
+			plurals := make(map[string]interface{})
+			for _, unit := range e.Elements {
+				if unit.XMLName.Local != "trans-unit" && unit.XMLName.Local != "unit" {
+					continue
+				}
+				plural := unit.Resname
+				if plural == "" {
+					plural = unit.Name
+				}
+				plurals[plural] = unit.target()
+			}
+			data := e.translationData(plurals)
+			if ordinal {
+				data["ordinal"] = true
+			}
+			translations = append(translations, data)
+		}
+	}
+	return translations
+}
+
+// translationData returns the translation of a unit or group in the standard format.
+func (e *xliffElement) translationData(translation interface{}) map[string]interface{} {
+	data := map[string]interface{}{
+		"id":          e.unitID(),
+		"translation": translation,
+	}
+	if context := e.context(); context != "" {
+		data["context"] = context
+	}
+	if description := e.description(); description != "" {
+		data["description"] = description
+	}
+	if maxLength := e.maxLength(); maxLength > 0 {
+		data["maxLength"] = maxLength
+	}
+	return data
+}
+
+// unitID returns the translation id of a unit or group.
+// XLIFF 2.0 ids must be NMTOKENs, so the translation id is stored in the name attribute.
+// XLIFF 1.2 ids must be unique, so the translation id of a unit with a context is stored in the resname attribute.
+func (e *xliffElement) unitID() string {
+	if e.Name != "" {
+		return e.Name
+	}
+	if e.Resname != "" {
+		return e.Resname
+	}
+	return e.ID
+}
+
+// context returns the context of a unit or group, or "" if it has none.
+func (e *xliffElement) context() string {
+	if e.Contexts != nil {
+		for _, c := range e.Contexts.Contexts {
+			if c.Type == xliff12ContextType {
+				return c.Value
+			}
+		}
+	}
+	return e.note20(xliff20ContextCategory)
+}
+
+// description returns the description of a unit or group, or "" if it has none.
+func (e *xliffElement) description() string {
+	if len(e.Note) > 0 {
+		return e.Note[0].Value
+	}
+	return e.note20(xliff20DescriptionCategory)
+}
+
+// maxLength returns the maximum length in characters of a unit or group, or 0 if it has none.
+func (e *xliffElement) maxLength() int {
+	if e.MaxWidth > 0 && e.SizeUnit == xliffCharSizeUnit {
+		return e.MaxWidth
+	}
+	n, _ := strconv.Atoi(e.note20(xliff20MaxLengthCategory))
+	return n
+}
+
+// note20 returns the value of the XLIFF 2.0 note of category, or "" if there is none.
+func (e *xliffElement) note20(category string) string {
+	if e.Notes != nil {
+		for _, n := range e.Notes.Notes {
+			if n.Category == category {
+				return n.Value
+			}
+		}
+	}
+	return ""
+}
+
+// setMetadata12 stores the context, description and maximum length of a translation in an XLIFF 1.2 trans-unit or group.
+// Its id becomes unique by prefixing the context, and the translation id is stored in the resname attribute.
+// The description is a note and the maximum length is a maxwidth in characters.
+func (e *xliffElement) setMetadata12(data map[string]interface{}) {
+	if context := translationContext(data); context != "" {
+		e.Resname = e.ID
+		e.ID = context + ":" + e.ID
+		e.Contexts = &xliffContextGroup{[]xliffContext{{xliff12ContextType, context}}}
+	}
+	if description, _ := data["description"].(string); description != "" {
+		e.Note = []xliffNote{{Value: description}}
+	}
+	if maxLength := translationMaxLength(data); maxLength > 0 {
+		e.MaxWidth = maxLength
+		e.SizeUnit = xliffCharSizeUnit
+	}
+}
+
+// setMetadata20 stores the context, description and maximum length of a translation
+// as notes of an XLIFF 2.0 unit or group.
+func (e *xliffElement) setMetadata20(data map[string]interface{}) {
+	var notes []xliffNote
+	if context := translationContext(data); context != "" {
+		notes = append(notes, xliffNote{xliff20ContextCategory, context})
+	}
+	if description, _ := data["description"].(string); description != "" {
+		notes = append(notes, xliffNote{xliff20DescriptionCategory, description})
+	}
+	if maxLength := translationMaxLength(data); maxLength > 0 {
+		notes = append(notes, xliffNote{xliff20MaxLengthCategory, strconv.Itoa(maxLength)})
+	}
+	if len(notes) > 0 {
+		e.Notes = &xliffNotes{notes}
+	}
+}
+
+// target returns the target text of a 1.2 trans-unit or the joined target texts of the segments of a 2.0 unit.
+func (e *xliffElement) target() string {
+	if e.Target != nil {
+		return *e.Target
+	}
+	var target string
+	for _, segment := range e.Elements {
+		if segment.XMLName.Local == "segment" && segment.Target != nil {
+			target += *segment.Target
+		}
+	}
+	return target
+}
+
+// xliffUnit is a translation, or a form of a plural translation, that is written as a unit.
+type xliffUnit struct {
+	id     string
+	plural language.Plural
+	source string
+	target string
+}
+
+// xliffUnits returns the units of a translation in the standard format.
+// There is one unit per form of a plural translation.
+//
+// The source of a unit is the matching form of the "source" key, falling back to the "other" form.
+// If there is no "source" key, then the translation is its own source.
+func xliffUnits(data map[string]interface{}) []xliffUnit {
+	id := translationID(data)
+	targets := translationForms(data["translation"])
+	source, ok := data["source"]
+	if !ok {
+		source = data["translation"]
+	}
+	sources := make(map[language.Plural]string)
+	for _, form := range translationForms(source) {
+		sources[form.plural] = form.text
+	}
+	units := make([]xliffUnit, len(targets))
+	for i, target := range targets {
+		src, ok := sources[target.plural]
+		if !ok {
+			src = sources[language.Other]
+		}
+		units[i] = xliffUnit{id, target.plural, src, target.text}
+	}
+	return units
+}
+
+func translationID(data map[string]interface{}) string {
+	id, _ := data["id"].(string)
+	return id
+}
+
+func translationContext(data map[string]interface{}) string {
+	context, _ := data["context"].(string)
+	return context
+}
+
+// translationMaxLength returns the "maxLength" key of data, or 0 if it has none.
+func translationMaxLength(data map[string]interface{}) int {
+	switch n := data["maxLength"].(type) {
+	case int:
+		return n
+	case float64:
+		return int(n)
+	}
+	return 0
+}
+
+func newXLIFFElement(name, source, target string) xliffElement {
+	e := xliffElement{XMLName: xml.Name{Local: name}, Source: &source}
+	if target != "" {
+		e.Target = &target
+	}
+	return e
+}
+
+// encodeXLIFF12 encodes translations in the standard format, or a *Catalog, as an XLIFF 1.2 file.
+//
+// Each translation becomes a trans-unit whose id is the translation id.
+// Plural translations become a group with one trans-unit per plural category,
+// whose restype is x-i18n-ordinals instead of x-gettext-plurals for ordinal translations.
+// The context of a translation is written as an x-gettext-msgctxt context, its description as a note
+// and its maximum length as a maxwidth in characters.
+// Untranslated units have no target.
+func encodeXLIFF12(v interface{}) ([]byte, error) {
+	catalog, err := toCatalog(v, "XLIFF")
+	if err != nil {
+		return nil, err
+	}
+	body := &xliffElement{}
+	for i, t := range catalog.Translations {
+		data, ok := stringMap(t)
+		if !ok {
+			return nil, fmt.Errorf("translation #%d is %T; expected an object", i, t)
+		}
+		units := xliffUnits(data)
+		if len(units) == 1 && units[0].plural == "" {
+			unit := newXLIFFElement("trans-unit", units[0].source, units[0].target)
+			unit.ID = units[0].id
+			unit.setMetadata12(data)
+			body.Elements = append(body.Elements, unit)
+			continue
+		}
+		group := xliffElement{XMLName: xml.Name{Local: "group"}, ID: translationID(data), Restype: xliff12PluralGroup}
+		if data["ordinal"] == true {
+			group.Restype = xliff12OrdinalGroup
+		}
+		group.setMetadata12(data)
+		for _, u := range units {
+			unit := newXLIFFElement("trans-unit", u.source, u.target)
+			unit.ID = fmt.Sprintf("%s[%s]", group.ID, u.plural)
+			unit.Resname = string(u.plural)
+			group.Elements = append(group.Elements, unit)
+		}
+		body.Elements = append(body.Elements, group)
+	}
+	return marshalXLIFF(&xliffDocument{
+		Xmlns:   xliff12Namespace,
+		Version: "1.2",
+		Files: []xliffFile{{
+			Original:       catalog.Language,
+			SourceLanguage: catalog.SourceLanguage,
+			TargetLanguage: catalog.Language,
+			Datatype:       "plaintext",
+			Body:           body,
+		}},
+	})
+}
+
+// encodeXLIFF20 encodes translations in the standard format, or a *Catalog, as an XLIFF 2.0 file.
+//
+// Each translation becomes a unit whose name is the translation id.
+// Plural translations become a group with one unit per plural category,
+// whose type is i18n:ordinal instead of i18n:plural for ordinal translations.
+// The context, description and maximum length of a translation are written as notes
+// of the "context", "description" and "maxLength" categories.
+// Untranslated units have no target.
+func encodeXLIFF20(v interface{}) ([]byte, error) {
+	catalog, err := toCatalog(v, "XLIFF")
+	if err != nil {
+		return nil, err
+	}
+	file := xliffFile{ID: "f1"}
+	n := 0
+	nextID := func(prefix string) string {
+		n++
+		return fmt.Sprintf("%s%d", prefix, n)
+	}
+	newUnit := func(name string, u xliffUnit) xliffElement {
+		segment := newXLIFFElement("segment", u.source, u.target)
+		return xliffElement{
+			XMLName:  xml.Name{Local: "unit"},
+			ID:       nextID("u"),
+			Name:     name,
+			Elements: []xliffElement{segment},
+		}
+	}
+	for i, t := range catalog.Translations {
+		data, ok := stringMap(t)
+		if !ok {
+			return nil, fmt.Errorf("translation #%d is %T; expected an object", i, t)
+		}
+		units := xliffUnits(data)
+		if len(units) == 1 && units[0].plural == "" {
+			unit := newUnit(units[0].id, units[0])
+			unit.setMetadata20(data)
+			file.Elements = append(file.Elements, unit)
+			continue
+		}
+		group := xliffElement{XMLName: xml.Name{Local: "group"}, ID: nextID("g"), Name: translationID(data), Type: xliff20PluralGroup}
+		if data["ordinal"] == true {
+			group.Type = xliff20OrdinalGroup
+		}
+		group.setMetadata20(data)
+		for _, u := range units {
+			group.Elements = append(group.Elements, newUnit(string(u.plural), u))
+		}
+		file.Elements = append(file.Elements, group)
+	}
+	return marshalXLIFF(&xliffDocument{
+		Xmlns:   xliff20Namespace,
+		Version: "2.0",
+		SrcLang: &catalog.SourceLanguage,
+		TrgLang: catalog.Language,
+		Files:   []xliffFile{file},
+	})
+}
+
+func marshalXLIFF(doc *xliffDocument) ([]byte, error) {
+	buf, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	out.WriteString(xml.Header)
+	out.Write(buf)
+	out.WriteString("\n")
+	return out.Bytes(), nil
+}
@@ -0,0 +1,43 @@
+//go:build go1.7
+// +build go1.7
+
+package i18n_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nicksnyder/go-i18n/i18n"
+	"github.com/nicksnyder/go-i18n/i18n/bundle"
+	"github.com/nicksnyder/go-i18n/i18n/language"
+)
+
+func greet(ctx context.Context, person string) string {
+	return i18n.T(ctx, "person_greeting", map[string]interface{}{"Person": person})
+}
+
+func ExampleT() {
+	i18n.MustLoadTranslationFile("../goi18n/testdata/expected/en-us.all.json")
+
+	ctx := i18n.WithLanguage(context.Background(), language.MustParse("en-US")[0])
+	fmt.Println(greet(ctx, "Bob"))
+
+	b := bundle.New()
+	b.ParseTranslationFileBytes("fr-FR.json", []byte(`[{"id": "person_greeting", "translation": "Bonjour {{.Person}}"}]`))
+	ctx = i18n.WithLanguage(i18n.WithBundle(context.Background(), b), language.MustParse("fr-FR")[0])
+	fmt.Println(greet(ctx, "Bob"))
+	fmt.Println(i18n.LanguageFromContext(ctx))
+	// Output:
+	// Hello Bob
+	// Bonjour Bob
+	// fr-fr
+}
+
+func ExampleWithTfunc() {
+	i18n.MustLoadTranslationFile("../goi18n/testdata/expected/en-us.all.json")
+
+	ctx := i18n.WithTfunc(context.Background(), i18n.MustTfunc("en-US"))
+	fmt.Println(i18n.T(ctx, "program_greeting"))
+	// Output:
+	// Hello world
+}
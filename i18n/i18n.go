@@ -8,6 +8,10 @@
 // If your translations are in a file format not supported by (Must)?LoadTranslationFile,
 // then you can use the AddTranslation function to manually add translations.
 //
+// Watch loads translation files and reloads them when they change, so translations can be edited
+// without restarting your program.
+//     w, err := i18n.Watch(5*time.Second, func(err error) { log.Print(err) }, "path/to/*.all.json")
+//
 // Fetching a translation
 //
 // Use Tfunc or MustTfunc to fetch a TranslateFunc that will return the translated string for a specific language.
@@ -19,6 +23,8 @@
 //         fmt.Println(T("Hello world"))
 //     }
 //
+// Package i18nhttp provides HTTP middleware that does this for every request.
+//
 // Usually it is a good idea to identify strings by a generic id rather than the English translation,
 // but the rest of this documentation will continue to use the English translation for readability.
 //     T("Hello world")     // ok
@@ -49,6 +55,14 @@
 //         "Timeframe": T("{{.Count}} days", 2),
 //     })
 //
+// Context
+//
+// Translations with the same id can be told apart by their context, like msgctxt in gettext.
+// Use ContextTfunc to fetch a ContextTranslateFunc that translates an id in a context.
+//     C, err := i18n.ContextTfunc(cookieLang, acceptLang, defaultLang)
+//     C("verb", "open")      // "Öffnen" in German
+//     C("adjective", "open") // "Offen" in German
+//
 // Templates
 //
 // You can use the .Funcs() method of a text/template or html/template to register a TranslateFunc
@@ -56,8 +70,11 @@
 package i18n
 
 import (
+	"time"
+
 	"github.com/nicksnyder/go-i18n/i18n/bundle"
 	"github.com/nicksnyder/go-i18n/i18n/language"
+	"github.com/nicksnyder/go-i18n/i18n/pseudo"
 	"github.com/nicksnyder/go-i18n/i18n/translation"
 )
 
@@ -66,6 +83,10 @@ import (
 // If there is no translation for translationID, then the translationID itself is returned.
 // This makes it easy to identify missing translations in your app.
 //
+// To return a default message instead, pass a translation.Default as the first variadic argument
+// (e.g. T("person_greeting", translation.Default{Other: "Hello {{.Person}}"}, data)).
+// The other variadic arguments follow it.
+//
 // If translationID is a non-plural form, then the first variadic argument may be a map[string]interface{}
 // or struct that contains template data.
 //
@@ -78,8 +99,19 @@ import (
 // data must be a struct{} or map[string]interface{} that contains a Count field and the template data,
 // Count field must be an integer type (int, int8, int16, int32, int64)
 // or a float formatted as a string (e.g. "123.45").
+// 3. T(numberRange language.Range, data struct{})
+// The plural form is chosen by the CLDR plural range rules for the start and end of numberRange
+// (e.g. T("d_days", language.Range{Start: 1, End: 3}) for "1–3 days").
+// The start and end are available to the template as Start and End.
+// The second variadic argument may be a map[string]interface{} or struct{} that contains template data.
 type TranslateFunc func(translationID string, args ...interface{}) string
 
+// ContextTranslateFunc is similar to TranslateFunc except it returns the translation of the string
+// identified by translationID in context (e.g. "verb" or "adjective").
+//
+// If there is no translation for translationID in context, then the translationID itself is returned.
+type ContextTranslateFunc func(context, translationID string, args ...interface{}) string
+
 // IdentityTfunc returns a TranslateFunc that always returns the translationID passed to it.
 //
 // It is a useful placeholder when parsing a text/template or html/template
@@ -121,6 +153,19 @@ func AddTranslation(lang *language.Language, translations ...translation.Transla
 	defaultBundle.AddTranslation(lang, translations...)
 }
 
+// SetTranslations replaces all translations of a language with translations.
+// The language is removed if translations is empty.
+func SetTranslations(lang *language.Language, translations ...translation.Translation) {
+	defaultBundle.SetTranslations(lang, translations...)
+}
+
+// Watch loads the translation files that match patterns (see filepath.Glob),
+// then reloads them when they change until the watcher is closed (see bundle.Bundle.Watch).
+// A file that fails to reload keeps its last good translations, and onError is called with the error.
+func Watch(interval time.Duration, onError func(error), patterns ...string) (*bundle.Watcher, error) {
+	return defaultBundle.Watch(interval, onError, patterns...)
+}
+
 // LanguageTags returns the tags of all languages that have been added.
 func LanguageTags() []string {
 	return defaultBundle.LanguageTags()
@@ -131,20 +176,67 @@ func LanguageTranslationIDs(languageTag string) []string {
 	return defaultBundle.LanguageTranslationIDs(languageTag)
 }
 
+// SetPseudo makes every translation pseudo-localized with m when it is translated (see package pseudo).
+func SetPseudo(m pseudo.Method) {
+	defaultBundle.SetPseudo(m)
+}
+
+// ClearPseudo stops the pseudo-localization started by SetPseudo.
+func ClearPseudo() {
+	defaultBundle.ClearPseudo()
+}
+
+// SetDefaultLanguage sets the language whose translations are used when none of the preferred languages
+// of a TranslateFunc has a translation for a translation id.
+func SetDefaultLanguage(tag string) error {
+	return defaultBundle.SetDefaultLanguage(tag)
+}
+
+// AddFallback makes TranslateFuncs use the translations of fallbackTags, in order,
+// when the language tag has no translation for a translation id (e.g. AddFallback("pt-BR", "pt-PT")).
+func AddFallback(tag string, fallbackTags ...string) error {
+	return defaultBundle.AddFallback(tag, fallbackTags...)
+}
+
+// OnMissing registers f to be called whenever a TranslateFunc returns the translation id
+// instead of a translation, with the reason why (see bundle.MissingReason).
+// lang is nil if the language is unknown. OnMissing(nil) removes f.
+func OnMissing(f func(lang *language.Language, translationID string, reason bundle.MissingReason)) {
+	defaultBundle.OnMissing(f)
+}
+
+// Localize returns the translation of translationID in lang with args, like a TranslateFunc,
+// except it returns a *bundle.TranslateError instead of falling back to translationID.
+func Localize(lang *language.Language, translationID string, args ...interface{}) (string, error) {
+	return defaultBundle.Localize(lang, translationID, args...)
+}
+
 // MustTfunc is similar to Tfunc except it panics if an error happens.
 func MustTfunc(languageSource string, languageSources ...string) TranslateFunc {
 	return TranslateFunc(defaultBundle.MustTfunc(languageSource, languageSources...))
 }
 
 // Tfunc returns a TranslateFunc that will be bound to the first language which
-// has a non-zero number of translations.
+// has a non-zero number of translations, or else to the default language (see SetDefaultLanguage).
+// Translation ids that the language has no translation for fall back to other languages (see AddFallback).
 //
-// It can parse languages from Accept-Language headers (RFC 2616).
+// It can parse languages from Accept-Language headers (RFC 7231) and orders them by their quality values.
 func Tfunc(languageSource string, languageSources ...string) (TranslateFunc, error) {
 	tfunc, err := defaultBundle.Tfunc(languageSource, languageSources...)
 	return TranslateFunc(tfunc), err
 }
 
+// MustContextTfunc is similar to ContextTfunc except it panics if an error happens.
+func MustContextTfunc(languageSource string, languageSources ...string) ContextTranslateFunc {
+	return ContextTranslateFunc(defaultBundle.MustContextTfunc(languageSource, languageSources...))
+}
+
+// ContextTfunc is similar to Tfunc except it returns a ContextTranslateFunc.
+func ContextTfunc(languageSource string, languageSources ...string) (ContextTranslateFunc, error) {
+	tfunc, err := defaultBundle.ContextTfunc(languageSource, languageSources...)
+	return ContextTranslateFunc(tfunc), err
+}
+
 // MustTfuncAndLanguage is similar to TfuncAndLanguage except it panics if an error happens.
 func MustTfuncAndLanguage(languageSource string, languageSources ...string) (TranslateFunc, *language.Language) {
 	tfunc, lang := defaultBundle.MustTfuncAndLanguage(languageSource, languageSources...)
@@ -0,0 +1,87 @@
+//go:build go1.7
+// +build go1.7
+
+package i18n
+
+import (
+	"context"
+
+	"github.com/nicksnyder/go-i18n/i18n/bundle"
+	"github.com/nicksnyder/go-i18n/i18n/language"
+)
+
+type bundleKey struct{}
+
+type languageKey struct{}
+
+type tfuncKey struct{}
+
+// WithBundle returns a copy of ctx in which T translates with b instead of the default bundle.
+func WithBundle(ctx context.Context, b *bundle.Bundle) context.Context {
+	return context.WithValue(withoutTfunc(ctx), bundleKey{}, b)
+}
+
+// BundleFromContext returns the bundle of ctx (see WithBundle), or nil if ctx has none.
+func BundleFromContext(ctx context.Context) *bundle.Bundle {
+	b, _ := ctx.Value(bundleKey{}).(*bundle.Bundle)
+	return b
+}
+
+// WithLanguage returns a copy of ctx in which T translates into lang.
+func WithLanguage(ctx context.Context, lang *language.Language) context.Context {
+	return context.WithValue(withoutTfunc(ctx), languageKey{}, lang)
+}
+
+// WithTfunc returns a copy of ctx in which T translates with tfunc,
+// for example a TranslateFunc of all the languages that a user prefers (see Tfunc).
+// WithBundle and WithLanguage replace tfunc.
+func WithTfunc(ctx context.Context, tfunc TranslateFunc) context.Context {
+	return context.WithValue(ctx, tfuncKey{}, tfunc)
+}
+
+// TfuncFromContext returns the TranslateFunc of ctx (see WithTfunc),
+// or else a TranslateFunc that translates like T with the bundle and language of ctx.
+func TfuncFromContext(ctx context.Context) TranslateFunc {
+	if tfunc, _ := ctx.Value(tfuncKey{}).(TranslateFunc); tfunc != nil {
+		return tfunc
+	}
+	return func(translationID string, args ...interface{}) string {
+		return T(ctx, translationID, args...)
+	}
+}
+
+// withoutTfunc returns a copy of ctx without the TranslateFunc of WithTfunc, if it has one.
+func withoutTfunc(ctx context.Context) context.Context {
+	if tfunc, _ := ctx.Value(tfuncKey{}).(TranslateFunc); tfunc == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, tfuncKey{}, TranslateFunc(nil))
+}
+
+// LanguageFromContext returns the language of ctx (see WithLanguage), or nil if ctx has none.
+func LanguageFromContext(ctx context.Context) *language.Language {
+	lang, _ := ctx.Value(languageKey{}).(*language.Language)
+	return lang
+}
+
+// T returns the translation of translationID in the language of ctx with args, like a TranslateFunc.
+//
+// The translation is looked up in the bundle of ctx, or in the default bundle if ctx has none,
+// so code that only has a context can translate:
+//
+//	ctx = i18n.WithLanguage(ctx, language.MustParse("fr-FR")[0])
+//	...
+//	fmt.Println(i18n.T(ctx, "program_greeting"))
+//
+// If ctx has a TranslateFunc (see WithTfunc), T translates with it instead.
+// If ctx has neither, only the default language and default messages can translate translationID.
+func T(ctx context.Context, translationID string, args ...interface{}) string {
+	if tfunc, _ := ctx.Value(tfuncKey{}).(TranslateFunc); tfunc != nil {
+		return tfunc(translationID, args...)
+	}
+	b := BundleFromContext(ctx)
+	if b == nil {
+		b = defaultBundle
+	}
+	return b.Translate(LanguageFromContext(ctx), translationID, args...)
+}
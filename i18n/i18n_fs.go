@@ -0,0 +1,23 @@
+//go:build go1.16
+// +build go1.16
+
+package i18n
+
+import "io/fs"
+
+// MustLoadFS is similar to LoadFS except it panics if an error happens.
+func MustLoadFS(fsys fs.FS, patterns ...string) {
+	defaultBundle.MustLoadFS(fsys, patterns...)
+}
+
+// LoadFS loads the translation files in fsys that match any of patterns into memory.
+// If no patterns are given, every file in fsys is loaded.
+// Files whose extension has no registered decoder are skipped.
+//
+// The language that the translations are associated with is parsed from each filename (e.g. en-US.json).
+//
+// It is useful for loading translation files embedded with go:embed.
+// It requires Go 1.16 or later.
+func LoadFS(fsys fs.FS, patterns ...string) error {
+	return defaultBundle.LoadFS(fsys, patterns...)
+}
@@ -0,0 +1,20 @@
+// Package i18nhttp negotiates the language of HTTP requests.
+//
+// Middleware resolves the language of each request from a chain of sources,
+// such as a query parameter, a cookie and the Accept-Language header, against a bundle:
+//
+//	b := bundle.New()
+//	b.MustLoadTranslationFile("path/to/en-US.all.json")
+//	b.SetDefaultLanguage("en-US")
+//	handler := i18nhttp.Middleware(b, i18nhttp.Query("lang"), i18nhttp.Cookie("lang"), i18nhttp.AcceptLanguage)(mux)
+//
+// Handlers get the TranslateFunc and the language of the request from its context
+// with the context functions of package i18n:
+//
+//	func hello(w http.ResponseWriter, r *http.Request) {
+//	    T := i18n.TfuncFromContext(r.Context())
+//	    fmt.Fprintln(w, T("program_greeting"), i18n.LanguageFromContext(r.Context()))
+//	}
+//
+// The package requires Go 1.7 or later.
+package i18nhttp
@@ -0,0 +1,130 @@
+//go:build go1.7
+// +build go1.7
+
+package i18nhttp
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/nicksnyder/go-i18n/i18n"
+	"github.com/nicksnyder/go-i18n/i18n/bundle"
+	"github.com/nicksnyder/go-i18n/i18n/language"
+)
+
+// Source is a language preference of a request.
+type Source struct {
+	// Language returns a language tag or an Accept-Language header value of r, or "" if r has none.
+	Language func(r *http.Request) string
+
+	// Vary is the name of the request header that Language reads, if any.
+	// Middleware adds it to the Vary header of the response, so caches store a response per language.
+	Vary string
+}
+
+// AcceptLanguage is the Accept-Language header of a request.
+var AcceptLanguage = Header("Accept-Language")
+
+// Query returns the Source of the query parameter name (e.g. "lang" for /path?lang=fr-FR).
+func Query(name string) Source {
+	return Source{Language: func(r *http.Request) string {
+		return r.URL.Query().Get(name)
+	}}
+}
+
+// Cookie returns the Source of the value of the cookie name.
+func Cookie(name string) Source {
+	return Source{
+		Language: func(r *http.Request) string {
+			c, err := r.Cookie(name)
+			if err != nil {
+				return ""
+			}
+			return c.Value
+		},
+		Vary: "Cookie",
+	}
+}
+
+// Header returns the Source of the request header name.
+func Header(name string) Source {
+	return Source{
+		Language: func(r *http.Request) string {
+			return r.Header.Get(name)
+		},
+		Vary: name,
+	}
+}
+
+// Default returns the Source of the language tag, which every request has.
+// It is useful at the end of a chain of sources; the default language of the bundle
+// (see bundle.Bundle.SetDefaultLanguage) is used when no source has a supported language.
+func Default(tag string) Source {
+	return Source{Language: func(r *http.Request) string {
+		return tag
+	}}
+}
+
+// DefaultSources are the sources of Middleware when it is called without sources:
+// the query parameter "lang", the cookie "lang" and the Accept-Language header.
+var DefaultSources = []Source{Query("lang"), Cookie("lang"), AcceptLanguage}
+
+// Middleware returns a function that wraps a handler with language negotiation.
+//
+// For each request, the languages of sources, in order, are resolved against b as by b.TfuncAndLanguage.
+// b, the language and the TranslateFunc are stored in the context of the request
+// (see i18n.WithBundle, i18n.WithLanguage and i18n.WithTfunc), so handlers translate with i18n.T or i18n.TfuncFromContext,
+// the Content-Language header of the response is set to the language,
+// and the Vary header of the response lists the request headers of sources.
+func Middleware(b *bundle.Bundle, sources ...Source) func(http.Handler) http.Handler {
+	if len(sources) == 0 {
+		sources = DefaultSources
+	}
+	var vary []string
+	for _, s := range sources {
+		if s.Vary != "" && !contains(vary, s.Vary) {
+			vary = append(vary, s.Vary)
+		}
+	}
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var prefs []string
+			for _, s := range sources {
+				if pref := s.Language(r); pref != "" {
+					prefs = append(prefs, pref)
+				}
+			}
+			if len(prefs) == 0 {
+				prefs = append(prefs, "")
+			}
+			tfunc, lang, _ := b.TfuncAndLanguage(prefs[0], prefs[1:]...)
+
+			header := w.Header()
+			for _, v := range vary {
+				header.Add("Vary", v)
+			}
+			if lang != nil {
+				header.Set("Content-Language", contentLanguage(lang))
+			}
+			ctx := i18n.WithTfunc(i18n.WithLanguage(i18n.WithBundle(r.Context(), b), lang), i18n.TranslateFunc(tfunc))
+			h.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// contentLanguage returns the tag of lang in the canonical case of its subtags (e.g. "fr-CA").
+func contentLanguage(lang *language.Language) string {
+	if t, err := lang.ParsedTag(); err == nil {
+		return t.String()
+	}
+	return lang.Tag
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
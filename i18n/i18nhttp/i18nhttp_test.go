@@ -0,0 +1,107 @@
+//go:build go1.7
+// +build go1.7
+
+package i18nhttp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/i18n"
+	"github.com/nicksnyder/go-i18n/i18n/bundle"
+	"github.com/nicksnyder/go-i18n/i18n/language"
+)
+
+func TestMiddleware(t *testing.T) {
+	b := bundle.New()
+	for filename, content := range map[string]string{
+		"en-US.json": `[{"id": "hello", "translation": "Hello"}]`,
+		"fr-CA.json": `[{"id": "hello", "translation": "Allô"}]`,
+		"de.json":    `[{"id": "hello", "translation": "Hallo"}, {"id": "bye", "translation": "Tschüss"}]`,
+	} {
+		if err := b.ParseTranslationFileBytes(filename, []byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := b.SetDefaultLanguage("en-US"); err != nil {
+		t.Fatal(err)
+	}
+	handler := Middleware(b)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, id := range []string{"hello", "bye"} {
+			if s, expected := i18n.T(r.Context(), id), i18n.TfuncFromContext(r.Context())(id); s != expected {
+				t.Errorf("i18n.T(%s) = %q; expected %q", id, s, expected)
+			}
+		}
+		// A language set by the handler replaces the languages of the request.
+		if s := i18n.T(i18n.WithLanguage(r.Context(), language.MustParse("de")[0]), "hello"); s != "Hallo" {
+			t.Errorf("i18n.T(hello) = %q in de; expected %q", s, "Hallo")
+		}
+		fmt.Fprintf(w, "%s %s %s", i18n.LanguageFromContext(r.Context()), i18n.TfuncFromContext(r.Context())("hello"), i18n.TfuncFromContext(r.Context())("bye"))
+	}))
+
+	tests := []struct {
+		url            string
+		cookie         string
+		acceptLanguage string
+		body           string
+		contentLang    string
+	}{
+		{"/", "", "", "en-us Hello bye", "en-US"},
+		{"/", "", "ja, de;q=0.9", "de Hallo Tschüss", "de"},
+		{"/", "fr-CA", "de", "fr-ca Allô Tschüss", "fr-CA"},
+		{"/?lang=de", "fr-CA", "fr-CA", "de Hallo Tschüss", "de"},
+		{"/?lang=xx", "", "fr-ca;q=0.5, ja", "fr-ca Allô bye", "fr-CA"},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest("GET", test.url, nil)
+		if test.cookie != "" {
+			r.AddCookie(&http.Cookie{Name: "lang", Value: test.cookie})
+		}
+		if test.acceptLanguage != "" {
+			r.Header.Set("Accept-Language", test.acceptLanguage)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		if body := w.Body.String(); body != test.body {
+			t.Errorf("%s %q %q: body = %q; expected %q", test.url, test.cookie, test.acceptLanguage, body, test.body)
+		}
+		if cl := w.Header().Get("Content-Language"); cl != test.contentLang {
+			t.Errorf("%s %q %q: Content-Language = %q; expected %q", test.url, test.cookie, test.acceptLanguage, cl, test.contentLang)
+		}
+		if vary := w.Header()["Vary"]; !reflect.DeepEqual(vary, []string{"Cookie", "Accept-Language"}) {
+			t.Errorf("Vary = %q; expected Cookie and Accept-Language", vary)
+		}
+	}
+}
+
+func TestMiddlewareUnsupported(t *testing.T) {
+	b := bundle.New()
+	handler := Middleware(b, Header("X-Language"), Default("ja"))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%v %s", i18n.LanguageFromContext(r.Context()), i18n.TfuncFromContext(r.Context())("hello"))
+	}))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	if body := w.Body.String(); body != "<nil> hello" {
+		t.Errorf("body = %q; expected %q", body, "<nil> hello")
+	}
+	if cl, ok := w.Header()["Content-Language"]; ok {
+		t.Errorf("Content-Language = %q; expected none", cl)
+	}
+	if vary := w.Header()["Vary"]; !reflect.DeepEqual(vary, []string{"X-Language"}) {
+		t.Errorf("Vary = %q; expected X-Language", vary)
+	}
+}
+
+func TestContext(t *testing.T) {
+	ctx := context.Background()
+	if lang := i18n.LanguageFromContext(ctx); lang != nil {
+		t.Errorf("LanguageFromContext = %v; expected nil", lang)
+	}
+	if s := i18n.TfuncFromContext(ctx)("hello"); s != "hello" {
+		t.Errorf("TfuncFromContext(hello) = %q; expected the translation id", s)
+	}
+}
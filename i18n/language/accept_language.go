@@ -0,0 +1,95 @@
+package language
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Preference is a language range of an Accept-Language header with its quality value.
+type Preference struct {
+	// Tag is a normalized language tag (see NormalizeTag), or "*" for any language.
+	Tag string
+
+	// Quality is the weight of the preference, greater than 0 and at most 1.
+	Quality float64
+}
+
+// ParseAcceptLanguage returns the preferences of an Accept-Language header value (RFC 7231, section 5.3.5)
+// sorted by decreasing quality. Preferences with the same quality keep their order.
+//
+// Preferences with a quality of 0, which means "not acceptable", and malformed preferences are dropped.
+// A language range that appears more than once keeps its first preference.
+func ParseAcceptLanguage(src string) []Preference {
+	var prefs []Preference
+	found := make(map[string]bool)
+	for _, field := range strings.Split(src, ",") {
+		params := strings.Split(field, ";")
+		tag := NormalizeTag(strings.TrimSpace(params[0]))
+		if !isLanguageRange(tag) || found[tag] {
+			continue
+		}
+		quality, ok := parseQuality(params[1:])
+		if !ok {
+			continue
+		}
+		found[tag] = true
+		if quality > 0 {
+			prefs = append(prefs, Preference{Tag: tag, Quality: quality})
+		}
+	}
+	sort.Stable(byQuality(prefs))
+	return prefs
+}
+
+// Languages returns the supported languages of prefs in order, skipping wildcards.
+func Languages(prefs []Preference) []*Language {
+	var langs []*Language
+	for _, pref := range prefs {
+		if pref.Tag != "*" {
+			langs = append(langs, Parse(pref.Tag)...)
+		}
+	}
+	return dedupe(langs)
+}
+
+// isLanguageRange reports whether tag is a basic language range (RFC 4647, section 2.1).
+func isLanguageRange(tag string) bool {
+	if tag == "*" {
+		return true
+	}
+	for i, subtag := range strings.Split(tag, "-") {
+		if len(subtag) < 1 || len(subtag) > 8 {
+			return false
+		}
+		for _, r := range subtag {
+			if !('a' <= r && r <= 'z' || i > 0 && '0' <= r && r <= '9') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// parseQuality returns the quality value of the parameters of a language range, which is 1 if there is none.
+func parseQuality(params []string) (float64, bool) {
+	quality := 1.0
+	for _, param := range params {
+		kv := strings.SplitN(param, "=", 2)
+		if len(kv) != 2 || !strings.EqualFold(strings.TrimSpace(kv[0]), "q") {
+			return 0, false
+		}
+		q, err := strconv.ParseFloat(strings.TrimSpace(kv[1]), 64)
+		if err != nil || q < 0 || q > 1 {
+			return 0, false
+		}
+		quality = q
+	}
+	return quality, true
+}
+
+type byQuality []Preference
+
+func (p byQuality) Len() int           { return len(p) }
+func (p byQuality) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+func (p byQuality) Less(i, j int) bool { return p[i].Quality > p[j].Quality }
@@ -0,0 +1,43 @@
+package language
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		src   string
+		prefs []Preference
+	}{
+		{"", nil},
+		{"en-US", []Preference{{"en-us", 1}}},
+		{"en_US", []Preference{{"en-us", 1}}},
+		{
+			"fr-CH, fr;q=0.9, en;q=0.8, de;q=0.7, *;q=0.5",
+			[]Preference{{"fr-ch", 1}, {"fr", 0.9}, {"en", 0.8}, {"de", 0.7}, {"*", 0.5}},
+		},
+		{
+			"de;q=0.7, en;Q=0.8, fr, es;q=0.8",
+			[]Preference{{"fr", 1}, {"en", 0.8}, {"es", 0.8}, {"de", 0.7}},
+		},
+		{"en;q=0, fr;q=0.000", nil},
+		{"en;q=0, en;q=1", nil},
+		{"zh-Hant-TW ; q=0.5 , zh", []Preference{{"zh", 1}, {"zh-hant-tw", 0.5}}},
+		{"en;q=1.5, fr;q=abc, de;level=1, it;q=0.8", []Preference{{"it", 0.8}}},
+		{"en-US.json, en US, -en, en-, 1en, , toolongtag, es-419", []Preference{{"es-419", 1}}},
+	}
+	for _, test := range tests {
+		if prefs := ParseAcceptLanguage(test.src); !reflect.DeepEqual(prefs, test.prefs) {
+			t.Errorf("ParseAcceptLanguage(%q) = %v expected %v", test.src, prefs, test.prefs)
+		}
+	}
+}
+
+func TestLanguages(t *testing.T) {
+	prefs := ParseAcceptLanguage("xx, *;q=0.9, en-GB;q=0.8, fr-FR, en-gb")
+	expected := []*Language{newLanguage("fr-fr", pluralSpecs["fr"]), newLanguage("en-gb", pluralSpecs["en"])}
+	if langs := Languages(prefs); !reflect.DeepEqual(langs, expected) {
+		t.Errorf("Languages(%v) = %v expected %v", prefs, langs, expected)
+	}
+}
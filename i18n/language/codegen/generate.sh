@@ -1,5 +1,10 @@
 #!/bin/sh
-go build && ./codegen -cout ../pluralspec_gen.go -tout ../pluralspec_gen_test.go && \
+go build && ./codegen -ranges pluralRanges.xml -cout ../pluralspec_gen.go -tout ../pluralspec_gen_test.go && \
+    ./codegen -i ordinals.xml -cout ../ordinalspec_gen.go -tout ../ordinalspec_gen_test.go && \
+    ./codegen -i likelySubtags.xml -cout ../likelysubtags_gen.go && \
     gofmt -w=true ../pluralspec_gen.go && \
     gofmt -w=true ../pluralspec_gen_test.go && \
+    gofmt -w=true ../ordinalspec_gen.go && \
+    gofmt -w=true ../ordinalspec_gen_test.go && \
+    gofmt -w=true ../likelysubtags_gen.go && \
     rm codegen
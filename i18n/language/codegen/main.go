@@ -6,10 +6,14 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"strings"
 	"text/template"
 )
 
-var usage = `%[1]s generates Go code to support CLDR plural rules.
+var usage = `%[1]s generates Go code to support CLDR plural rules and likely subtags.
+
+The input is either plurals.xml (cardinal rules), ordinals.xml (ordinal rules) or likelySubtags.xml.
+The plural ranges in pluralRanges.xml can be added to cardinal rules.
 
 Usage: %[1]s [options]
 
@@ -22,8 +26,9 @@ func main() {
 		fmt.Fprintf(os.Stderr, usage, os.Args[0])
 		flag.PrintDefaults()
 	}
-	var in, cout, tout string
-	flag.StringVar(&in, "i", "plurals.xml", "the input XML file containing CLDR plural rules")
+	var in, ranges, cout, tout string
+	flag.StringVar(&in, "i", "plurals.xml", "the input XML file containing CLDR plural or ordinal rules")
+	flag.StringVar(&ranges, "ranges", "", "the input XML file containing CLDR plural ranges")
 	flag.StringVar(&cout, "cout", "", "the code output file")
 	flag.StringVar(&tout, "tout", "", "the test output file")
 	flag.BoolVar(&verbose, "v", false, "verbose output")
@@ -39,15 +44,35 @@ func main() {
 		fatalf("failed to unmarshal xml: %s", err)
 	}
 
+	if len(data.LikelySubtags) > 0 {
+		infof("parsed %d likely subtags", len(data.LikelySubtags))
+		if cout == "" {
+			fatalf("need a code output file for likely subtags (use -cout)")
+		}
+		file := openWritableFile(cout)
+		if err := likelySubtagsTemplate.Execute(file, data.LikelySubtags); err != nil {
+			fatalf("unable to execute likely subtags template because %s", err)
+		}
+		infof("generated %s", cout)
+		return
+	}
+
 	count := 0
-	for _, pg := range data.PluralGroups {
+	for _, pg := range data.Plurals.PluralGroups {
 		count += len(pg.SplitLocales())
 	}
-	infof("parsed %d locales", count)
+	infof("parsed %d locales with %s rules", count, data.Plurals.Type)
+
+	if ranges != "" {
+		if data.Plurals.Ordinal() {
+			fatalf("plural ranges are only defined for cardinal rules")
+		}
+		data.Plurals.PluralRangeGroups = readPluralRanges(ranges, data.Plurals.PluralGroups)
+	}
 
 	if cout != "" {
 		file := openWritableFile(cout)
-		if err := codeTemplate.Execute(file, data); err != nil {
+		if err := codeTemplate.Execute(file, &data.Plurals); err != nil {
 			fatalf("unable to execute code template because %s", err)
 		} else {
 			infof("generated %s", cout)
@@ -58,7 +83,7 @@ func main() {
 
 	if tout != "" {
 		file := openWritableFile(tout)
-		if err := testTemplate.Execute(file, data); err != nil {
+		if err := testTemplate.Execute(file, &data.Plurals); err != nil {
 			fatalf("unable to execute test template because %s", err)
 		} else {
 			infof("generated %s", tout)
@@ -68,6 +93,62 @@ func main() {
 	}
 }
 
+// readPluralRanges reads the plural range groups in filename.
+// Locales that do not have plural rules in groups, or whose ranges use plural forms
+// that are not in their plural rules, are dropped.
+func readPluralRanges(filename string, groups []PluralGroup) []PluralRangeGroup {
+	buf, err := ioutil.ReadFile(filename)
+	if err != nil {
+		fatalf("failed to read file: %s", err)
+	}
+	var data SupplementalData
+	if err := xml.Unmarshal(buf, &data); err != nil {
+		fatalf("failed to unmarshal xml: %s", err)
+	}
+
+	counts := make(map[string]map[string]bool)
+	for _, pg := range groups {
+		pgCounts := make(map[string]bool)
+		for _, pr := range pg.PluralRules {
+			pgCounts[pr.Count] = true
+		}
+		for _, locale := range pg.SplitLocales() {
+			counts[locale] = pgCounts
+		}
+	}
+
+	var rangeGroups []PluralRangeGroup
+	count := 0
+	for _, prg := range data.Plurals.PluralRangeGroups {
+		var locales []string
+		for _, locale := range prg.SplitLocales() {
+			localeCounts := counts[locale]
+			if localeCounts == nil {
+				infof("skipping plural ranges of %s because it has no plural rules", locale)
+				continue
+			}
+			supported := true
+			for _, pr := range prg.PluralRanges {
+				if !localeCounts[pr.Start] || !localeCounts[pr.End] || !localeCounts[pr.Result] {
+					infof("skipping plural ranges of %s because it does not have the plural forms of %s-%s: %s", locale, pr.Start, pr.End, pr.Result)
+					supported = false
+					break
+				}
+			}
+			if supported {
+				locales = append(locales, locale)
+			}
+		}
+		if len(locales) > 0 {
+			prg.Locales = strings.Join(locales, " ")
+			rangeGroups = append(rangeGroups, prg)
+			count += len(locales)
+		}
+	}
+	infof("parsed plural ranges for %d locales", count)
+	return rangeGroups
+}
+
 func openWritableFile(name string) *os.File {
 	file, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
 	if err != nil {
@@ -81,7 +162,7 @@ var codeTemplate = template.Must(template.New("spec").Parse(`package language
 
 func init() {
 {{range .PluralGroups}}
-	RegisterPluralSpec({{printf "%#v" .SplitLocales}}, &PluralSpec{
+	Register{{$.SpecType}}({{printf "%#v" .SplitLocales}}, &{{$.SpecType}}{
 		Plurals: newPluralSet({{range $i, $e := .PluralRules}}{{if $i}}, {{end}}{{$e.CountTitle}}{{end}}),
 		PluralFunc: func(ops *Operands) Plural { {{range .PluralRules}}{{if .GoCondition}}
 			// {{.Condition}}
@@ -89,7 +170,11 @@ func init() {
 				return {{.CountTitle}}
 			}{{end}}{{end}}
 			return Other
-		},
+		},{{if not $.Ordinal}}
+		GettextPlural: {{printf "%q" .GettextPlural}},{{end}}
+	}){{end}}{{range .PluralRangeGroups}}
+	RegisterPluralRanges({{printf "%#v" .SplitLocales}}, map[[2]Plural]Plural{ {{range .PluralRanges}}
+		{ {{.StartTitle}}, {{.EndTitle}} }: {{.ResultTitle}},{{end}}
 	}){{end}}
 }
 `))
@@ -100,7 +185,7 @@ var testTemplate = template.Must(template.New("spec").Parse(`package language
 import "testing"
 
 {{range .PluralGroups}}
-func Test{{.Name}}(t *testing.T) {
+func Test{{if $.Ordinal}}Ordinal{{end}}{{.Name}}(t *testing.T) {
 	var tests []pluralTest
 	{{range .PluralRules}}
 	{{if .IntegerExamples}}tests = appendIntegerTests(tests, {{.CountTitle}}, {{printf "%#v" .IntegerExamples}}){{end}}
@@ -108,10 +193,30 @@ func Test{{.Name}}(t *testing.T) {
 	{{end}}
 	locales := {{printf "%#v" .SplitLocales}}
 	for _, locale := range locales {
-	  runTests(t, locale, tests)
+	  run{{if $.Ordinal}}Ordinal{{end}}Tests(t, locale, tests)
   }
 }
 {{end}}
+{{range .PluralRangeGroups}}
+func TestPluralRanges{{.Name}}(t *testing.T) {
+	tests := []pluralRangeTest{ {{range .PluralRanges}}
+		{ {{.StartTitle}}, {{.EndTitle}}, {{.ResultTitle}} },{{end}}
+	}
+	locales := {{printf "%#v" .SplitLocales}}
+	for _, locale := range locales {
+		runPluralRangeTests(t, locale, tests)
+	}
+}
+{{end}}
+`))
+
+var likelySubtagsTemplate = template.Must(template.New("likely").Parse(`package language
+// This file is generated by i18n/language/codegen/generate.sh
+
+// likelySubtags maps language tags to the tags with their likely script and region.
+var likelySubtags = map[string]string{ {{range .}}
+	{{printf "%q" .FromTag}}: {{printf "%q" .ToTag}},{{end}}
+}
 `))
 
 func infof(format string, args ...interface{}) {
@@ -4,13 +4,54 @@ import (
 	"encoding/xml"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
-// SupplementalData is the top level struct of plural.xml
+// SupplementalData is the top level struct of plural.xml, ordinals.xml and likelySubtags.xml
 type SupplementalData struct {
-	XMLName      xml.Name      `xml:"supplementalData"`
-	PluralGroups []PluralGroup `xml:"plurals>pluralRules"`
+	XMLName       xml.Name       `xml:"supplementalData"`
+	Plurals       Plurals        `xml:"plurals"`
+	LikelySubtags []LikelySubtag `xml:"likelySubtags>likelySubtag"`
+}
+
+// LikelySubtag maps a language tag to the tag with its likely script and region.
+type LikelySubtag struct {
+	From string `xml:"from,attr"`
+	To   string `xml:"to,attr"`
+}
+
+// FromTag returns the language tag of From with dashes.
+func (ls *LikelySubtag) FromTag() string {
+	return strings.Replace(ls.From, "_", "-", -1)
+}
+
+// ToTag returns the language tag of To with dashes.
+func (ls *LikelySubtag) ToTag() string {
+	return strings.Replace(ls.To, "_", "-", -1)
+}
+
+// Plurals contains the plural rules of one type.
+type Plurals struct {
+	// Type is "cardinal" for plurals.xml and "ordinal" for ordinals.xml.
+	Type         string        `xml:"type,attr"`
+	PluralGroups []PluralGroup `xml:"pluralRules"`
+
+	// PluralRangeGroups are read from pluralRanges.xml.
+	PluralRangeGroups []PluralRangeGroup `xml:"pluralRanges"`
+}
+
+// Ordinal returns true if the plural rules are ordinal rules.
+func (p *Plurals) Ordinal() bool {
+	return p.Type == "ordinal"
+}
+
+// SpecType returns the name of the Go type that implements the plural rules.
+func (p *Plurals) SpecType() string {
+	if p.Ordinal() {
+		return "OrdinalSpec"
+	}
+	return "PluralSpec"
 }
 
 // PluralGroup is a group of locales with the same plural rules.
@@ -30,6 +71,45 @@ func (pg *PluralGroup) SplitLocales() []string {
 	return strings.Split(pg.Locales, " ")
 }
 
+// PluralRangeGroup is a group of locales with the same plural range rules.
+type PluralRangeGroup struct {
+	Locales      string        `xml:"locales,attr"`
+	PluralRanges []PluralRange `xml:"pluralRange"`
+}
+
+// Name returns a unique name for this plural range group.
+func (prg *PluralRangeGroup) Name() string {
+	n := strings.Title(prg.Locales)
+	return strings.Replace(n, " ", "", -1)
+}
+
+// SplitLocales returns all the locales in the PluralRangeGroup as a slice.
+func (prg *PluralRangeGroup) SplitLocales() []string {
+	return strings.Split(prg.Locales, " ")
+}
+
+// PluralRange is the plural form of a range that starts and ends with numbers of the given plural forms.
+type PluralRange struct {
+	Start  string `xml:"start,attr"`
+	End    string `xml:"end,attr"`
+	Result string `xml:"result,attr"`
+}
+
+// StartTitle returns the title case of the PluralRange's start.
+func (pr *PluralRange) StartTitle() string {
+	return strings.Title(pr.Start)
+}
+
+// EndTitle returns the title case of the PluralRange's end.
+func (pr *PluralRange) EndTitle() string {
+	return strings.Title(pr.End)
+}
+
+// ResultTitle returns the title case of the PluralRange's result.
+func (pr *PluralRange) ResultTitle() string {
+	return strings.Title(pr.Result)
+}
+
 // PluralRule is the rule for a single plural form.
 type PluralRule struct {
 	Count string `xml:"count,attr"`
@@ -141,3 +221,114 @@ func (pr *PluralRule) GoCondition() string {
 	}
 	return strings.Join(ors, " ||\n")
 }
+
+// GettextPlural returns the plural expression of gettext's Plural-Forms header in C syntax,
+// which returns the index of the plural category of an integer n in CLDR order.
+func (pg *PluralGroup) GettextPlural() string {
+	counts := make(map[string]bool)
+	for _, pr := range pg.PluralRules {
+		counts[pr.Count] = true
+	}
+	counts["other"] = true
+	index := make(map[string]int)
+	for _, count := range []string{"zero", "one", "two", "few", "many", "other"} {
+		if counts[count] {
+			index[count] = len(index)
+		}
+	}
+	expr := strconv.Itoa(index["other"])
+	for i := len(pg.PluralRules) - 1; i >= 0; i-- {
+		pr := pg.PluralRules[i]
+		if pr.Count == "other" {
+			continue
+		}
+		switch cond := pr.CCondition(); cond {
+		case "0":
+		case "1":
+			expr = strconv.Itoa(index[pr.Count])
+		default:
+			expr = fmt.Sprintf("%s ? %d : %s", cond, index[pr.Count], expr)
+		}
+	}
+	return expr
+}
+
+// CCondition converts the XML condition to a C expression of an integer n, as used by gettext.
+// The operands v, w, f and t of integers are 0 and i is n.
+// It returns "1" if the condition is true for every integer and "0" if it is true for none.
+func (pr *PluralRule) CCondition() string {
+	var ors []string
+	for _, and := range strings.Split(pr.Condition(), "or") {
+		var ands []string
+		falseAnd := false
+		for _, relation := range strings.Split(and, "and") {
+			parts := relationRegexp.FindStringSubmatch(relation)
+			if parts == nil {
+				continue
+			}
+			r := cRelation(parts[1], parts[2], parts[3], strings.TrimSpace(parts[4]))
+			if r == "0" {
+				falseAnd = true
+				break
+			}
+			if r != "1" {
+				ands = append(ands, r)
+			}
+		}
+		switch {
+		case falseAnd:
+		case len(ands) == 0:
+			return "1"
+		case len(ands) == 1 || len(strings.Split(pr.Condition(), "or")) == 1:
+			ors = append(ors, strings.Join(ands, " && "))
+		default:
+			ors = append(ors, "("+strings.Join(ands, " && ")+")")
+		}
+	}
+	if len(ors) == 0 {
+		return "0"
+	}
+	return strings.Join(ors, " || ")
+}
+
+// cRelation converts a relation of a condition to a C expression of an integer n, or "1" or "0".
+func cRelation(operand, mod, op, rhs string) string {
+	if operand != "n" && operand != "i" {
+		// The operand is 0 for integers.
+		matches := false
+		for _, rh := range strings.Split(rhs, ",") {
+			if parts := strings.Split(rh, ".."); len(parts) == 2 {
+				matches = matches || parts[0] == "0"
+			} else {
+				matches = matches || rh == "0"
+			}
+		}
+		if matches == (op == "=") {
+			return "1"
+		}
+		return "0"
+	}
+	lhs := "n"
+	if mod != "" {
+		lhs = "n % " + mod
+	}
+	var rhor []string
+	for _, rh := range strings.Split(rhs, ",") {
+		if parts := strings.Split(rh, ".."); len(parts) == 2 {
+			rhor = append(rhor, fmt.Sprintf("%s >= %s && %s <= %s", lhs, parts[0], lhs, parts[1]))
+		} else {
+			rhor = append(rhor, fmt.Sprintf("%s == %s", lhs, rh))
+		}
+	}
+	if len(rhor) == 1 && op == "!=" && !strings.Contains(rhor[0], "&&") {
+		return strings.Replace(rhor[0], "==", "!=", 1)
+	}
+	r := strings.Join(rhor, " || ")
+	if len(rhor) > 1 || op == "!=" || strings.Contains(r, "&&") {
+		r = "(" + r + ")"
+	}
+	if op == "!=" {
+		r = "!" + r
+	}
+	return r
+}
@@ -15,29 +15,111 @@ type Language struct {
 	// (e.g. en, pt-br)
 	Tag string
 	*PluralSpec
+
+	// tag is Tag parsed as a BCP 47 language tag, or nil if it is not one
+	// or if the language was not created by Parse.
+	tag *Tag
+}
+
+func newLanguage(tag string, spec *PluralSpec) *Language {
+	l := &Language{Tag: NormalizeTag(tag), PluralSpec: spec}
+	if t, err := ParseTag(l.Tag); err == nil {
+		l.tag = &t
+	}
+	return l
 }
 
 func (l *Language) String() string {
 	return l.Tag
 }
 
-// MatchingTags returns the set of language tags that map to this Language.
-// e.g. "zh-hans-cn" yields {"zh", "zh-hans", "zh-hans-cn"}
-// BUG: This should be computed once and stored as a field on Language for efficiency,
-//      but this would require changing how Languages are constructed.
+// ParsedTag returns Tag parsed as a BCP 47 language tag (see ParseTag).
+// The languages returned by Parse parse their tag once, when they are created.
+func (l *Language) ParsedTag() (Tag, error) {
+	if l.tag != nil {
+		return *l.tag, nil
+	}
+	return ParseTag(l.Tag)
+}
+
+// OrdinalSpec returns the CLDR ordinal plural rules of the language.
+// Languages that CLDR does not define ordinal rules for only use Other.
+func (l *Language) OrdinalSpec() *OrdinalSpec {
+	if spec := GetOrdinalSpec(l.Tag); spec != nil {
+		return spec
+	}
+	return otherOrdinalSpec
+}
+
+// Ordinal returns the ordinal plural category for number
+// (e.g. One for 1st, Two for 2nd, Few for 3rd and Other for 4th in English).
+func (l *Language) Ordinal(number interface{}) (Plural, error) {
+	return l.OrdinalSpec().Plural(number)
+}
+
+// Range is a range of numbers, such as the 1–3 of "1–3 days".
+//
+// Start and End are integers or strings like the numbers that are passed to Plural.
+type Range struct {
+	Start interface{}
+	End   interface{}
+}
+
+// RangePlural returns the plural category of r as defined by the language's CLDR plural ranges.
+func (l *Language) RangePlural(r Range) (Plural, error) {
+	start, err := l.Plural(r.Start)
+	if err != nil {
+		return Invalid, err
+	}
+	end, err := l.Plural(r.End)
+	if err != nil {
+		return Invalid, err
+	}
+	return l.PluralRange(start, end), nil
+}
+
+// MatchingTags returns the set of language tags that map to this Language:
+// its tag and the parents of its tag (see Tag.Parent), from the least specific to the most specific,
+// followed by its tag with likely subtags (see Tag.Maximize) and the parents of that tag.
+// e.g. "zh-hans-cn" yields {"zh", "zh-hans", "zh-hans-cn"} and "zh-tw" yields {"zh-hant", "zh-tw", "zh-hant-tw"}
 func (l *Language) MatchingTags() []string {
-	parts := strings.Split(l.Tag, "-")
-	var prefix, matches []string
-	for _, part := range parts {
-		prefix = append(prefix, part)
-		match := strings.Join(prefix, "-")
-		matches = append(matches, match)
+	t, err := l.ParsedTag()
+	if err != nil {
+		// Tags that are not valid BCP 47 tags match their prefixes.
+		parts := strings.Split(l.Tag, "-")
+		var prefix, matches []string
+		for _, part := range parts {
+			prefix = append(prefix, part)
+			match := strings.Join(prefix, "-")
+			matches = append(matches, match)
+		}
+		return matches
+	}
+	matches := parentTags(t)
+	for _, tag := range parentTags(t.Maximize()) {
+		found := false
+		for _, match := range matches {
+			found = found || match == tag
+		}
+		if !found {
+			matches = append(matches, tag)
+		}
 	}
 	return matches
 }
 
+// parentTags returns the normalized tags of t and its parents, from the least specific to the most specific.
+func parentTags(t Tag) []string {
+	var tags []string
+	for ; !t.IsRoot(); t = t.Parent() {
+		tags = append([]string{NormalizeTag(t.String())}, tags...)
+	}
+	return tags
+}
+
 // Parse returns a slice of supported languages found in src or nil if none are found.
-// It can parse language tags and Accept-Language headers.
+// It can parse language tags and Accept-Language headers,
+// but it ignores quality values; use ParseAcceptLanguage for those.
 func Parse(src string) []*Language {
 	var langs []*Language
 	start := 0
@@ -46,7 +128,7 @@ func Parse(src string) []*Language {
 		case ',', ';', '.':
 			tag := strings.TrimSpace(src[start:end])
 			if spec := GetPluralSpec(tag); spec != nil {
-				langs = append(langs, &Language{NormalizeTag(tag), spec})
+				langs = append(langs, newLanguage(tag, spec))
 			}
 			start = end + 1
 		}
@@ -54,12 +136,12 @@ func Parse(src string) []*Language {
 	if start > 0 {
 		tag := strings.TrimSpace(src[start:])
 		if spec := GetPluralSpec(tag); spec != nil {
-			langs = append(langs, &Language{NormalizeTag(tag), spec})
+			langs = append(langs, newLanguage(tag, spec))
 		}
 		return dedupe(langs)
 	}
 	if spec := GetPluralSpec(src); spec != nil {
-		langs = append(langs, &Language{NormalizeTag(src), spec})
+		langs = append(langs, newLanguage(src, spec))
 	}
 	return langs
 }
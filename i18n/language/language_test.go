@@ -10,46 +10,46 @@ func TestParse(t *testing.T) {
 		src  string
 		lang []*Language
 	}{
-		{"en", []*Language{{"en", pluralSpecs["en"]}}},
-		{"en-US", []*Language{{"en-us", pluralSpecs["en"]}}},
-		{"en_US", []*Language{{"en-us", pluralSpecs["en"]}}},
-		{"en-GB", []*Language{{"en-gb", pluralSpecs["en"]}}},
-		{"zh-CN", []*Language{{"zh-cn", pluralSpecs["zh"]}}},
-		{"zh-TW", []*Language{{"zh-tw", pluralSpecs["zh"]}}},
-		{"pt-BR", []*Language{{"pt-br", pluralSpecs["pt"]}}},
-		{"pt_BR", []*Language{{"pt-br", pluralSpecs["pt"]}}},
-		{"pt-PT", []*Language{{"pt-pt", pluralSpecs["pt"]}}},
-		{"pt_PT", []*Language{{"pt-pt", pluralSpecs["pt"]}}},
-		{"zh-Hans-CN", []*Language{{"zh-hans-cn", pluralSpecs["zh"]}}},
-		{"zh-Hant-TW", []*Language{{"zh-hant-tw", pluralSpecs["zh"]}}},
-		{"en-US-en-US", []*Language{{"en-us-en-us", pluralSpecs["en"]}}},
-		{".en-US..en-US.", []*Language{{"en-us", pluralSpecs["en"]}}},
+		{"en", []*Language{newLanguage("en", pluralSpecs["en"])}},
+		{"en-US", []*Language{newLanguage("en-us", pluralSpecs["en"])}},
+		{"en_US", []*Language{newLanguage("en-us", pluralSpecs["en"])}},
+		{"en-GB", []*Language{newLanguage("en-gb", pluralSpecs["en"])}},
+		{"zh-CN", []*Language{newLanguage("zh-cn", pluralSpecs["zh"])}},
+		{"zh-TW", []*Language{newLanguage("zh-tw", pluralSpecs["zh"])}},
+		{"pt-BR", []*Language{newLanguage("pt-br", pluralSpecs["pt"])}},
+		{"pt_BR", []*Language{newLanguage("pt-br", pluralSpecs["pt"])}},
+		{"pt-PT", []*Language{newLanguage("pt-pt", pluralSpecs["pt"])}},
+		{"pt_PT", []*Language{newLanguage("pt-pt", pluralSpecs["pt"])}},
+		{"zh-Hans-CN", []*Language{newLanguage("zh-hans-cn", pluralSpecs["zh"])}},
+		{"zh-Hant-TW", []*Language{newLanguage("zh-hant-tw", pluralSpecs["zh"])}},
+		{"en-US-en-US", []*Language{newLanguage("en-us-en-us", pluralSpecs["en"])}},
+		{".en-US..en-US.", []*Language{newLanguage("en-us", pluralSpecs["en"])}},
 		{
 			"it, xx-zz, xx-ZZ, zh, en-gb;q=0.8, en;q=0.7, es-ES;q=0.6, de-xx",
 			[]*Language{
-				{"it", pluralSpecs["it"]},
-				{"zh", pluralSpecs["zh"]},
-				{"en-gb", pluralSpecs["en"]},
-				{"en", pluralSpecs["en"]},
-				{"es-es", pluralSpecs["es"]},
-				{"de-xx", pluralSpecs["de"]},
+				newLanguage("it", pluralSpecs["it"]),
+				newLanguage("zh", pluralSpecs["zh"]),
+				newLanguage("en-gb", pluralSpecs["en"]),
+				newLanguage("en", pluralSpecs["en"]),
+				newLanguage("es-es", pluralSpecs["es"]),
+				newLanguage("de-xx", pluralSpecs["de"]),
 			},
 		},
 		{
 			"it-qq,xx,xx-zz,xx-ZZ,zh,en-gb;q=0.8,en;q=0.7,es-ES;q=0.6,de-xx",
 			[]*Language{
-				{"it-qq", pluralSpecs["it"]},
-				{"zh", pluralSpecs["zh"]},
-				{"en-gb", pluralSpecs["en"]},
-				{"en", pluralSpecs["en"]},
-				{"es-es", pluralSpecs["es"]},
-				{"de-xx", pluralSpecs["de"]},
+				newLanguage("it-qq", pluralSpecs["it"]),
+				newLanguage("zh", pluralSpecs["zh"]),
+				newLanguage("en-gb", pluralSpecs["en"]),
+				newLanguage("en", pluralSpecs["en"]),
+				newLanguage("es-es", pluralSpecs["es"]),
+				newLanguage("de-xx", pluralSpecs["de"]),
 			},
 		},
-		{"en.json", []*Language{{"en", pluralSpecs["en"]}}},
-		{"en-US.json", []*Language{{"en-us", pluralSpecs["en"]}}},
-		{"en-us.json", []*Language{{"en-us", pluralSpecs["en"]}}},
-		{"en-xx.json", []*Language{{"en-xx", pluralSpecs["en"]}}},
+		{"en.json", []*Language{newLanguage("en", pluralSpecs["en"])}},
+		{"en-US.json", []*Language{newLanguage("en-us", pluralSpecs["en"])}},
+		{"en-us.json", []*Language{newLanguage("en-us", pluralSpecs["en"])}},
+		{"en-xx.json", []*Language{newLanguage("en-xx", pluralSpecs["en"])}},
 		{"xx-Yyen-US", nil},
 		{"en US", nil},
 		{"", nil},
@@ -74,8 +74,13 @@ func TestMatchingTags(t *testing.T) {
 		lang    *Language
 		matches []string
 	}{
-		{&Language{"zh-hans-cn", nil}, []string{"zh", "zh-hans", "zh-hans-cn"}},
-		{&Language{"foo", nil}, []string{"foo"}},
+		{newLanguage("zh-hans-cn", nil), []string{"zh", "zh-hans", "zh-hans-cn"}},
+		{newLanguage("foo", nil), []string{"foo"}},
+		{newLanguage("zh-tw", nil), []string{"zh-hant", "zh-tw", "zh-hant-tw"}},
+		{newLanguage("sr-latn", nil), []string{"sr-latn", "sr-latn-rs"}},
+		{newLanguage("sr-cyrl-rs", nil), []string{"sr", "sr-cyrl", "sr-cyrl-rs"}},
+		{newLanguage("en-us-u-ca-gregory", nil), []string{"en", "en-us", "en-us-u-ca-gregory", "en-latn", "en-latn-us", "en-latn-us-u-ca-gregory"}},
+		{newLanguage("en-us-en-us", nil), []string{"en", "en-us", "en-us-en", "en-us-en-us"}},
 	}
 	for _, test := range tests {
 		if actual := test.lang.MatchingTags(); !reflect.DeepEqual(test.matches, actual) {
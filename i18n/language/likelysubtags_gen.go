@@ -0,0 +1,260 @@
+package language
+
+// This file is generated by i18n/language/codegen/generate.sh
+
+// likelySubtags maps language tags to the tags with their likely script and region.
+var likelySubtags = map[string]string{
+	"af":       "af-Latn-ZA",
+	"ak":       "ak-Latn-GH",
+	"am":       "am-Ethi-ET",
+	"ar":       "ar-Arab-EG",
+	"as":       "as-Beng-IN",
+	"asa":      "asa-Latn-TZ",
+	"ast":      "ast-Latn-ES",
+	"az":       "az-Latn-AZ",
+	"az-Arab":  "az-Arab-IR",
+	"az-Cyrl":  "az-Cyrl-AZ",
+	"az-IQ":    "az-Arab-IQ",
+	"az-IR":    "az-Arab-IR",
+	"az-RU":    "az-Cyrl-RU",
+	"be":       "be-Cyrl-BY",
+	"bem":      "bem-Latn-ZM",
+	"bez":      "bez-Latn-TZ",
+	"bg":       "bg-Cyrl-BG",
+	"bm":       "bm-Latn-ML",
+	"bn":       "bn-Beng-BD",
+	"bo":       "bo-Tibt-CN",
+	"br":       "br-Latn-FR",
+	"brx":      "brx-Deva-IN",
+	"bs":       "bs-Latn-BA",
+	"ca":       "ca-Latn-ES",
+	"ce":       "ce-Cyrl-RU",
+	"cgg":      "cgg-Latn-UG",
+	"chr":      "chr-Cher-US",
+	"ckb":      "ckb-Arab-IQ",
+	"cs":       "cs-Latn-CZ",
+	"cy":       "cy-Latn-GB",
+	"da":       "da-Latn-DK",
+	"de":       "de-Latn-DE",
+	"dsb":      "dsb-Latn-DE",
+	"dv":       "dv-Thaa-MV",
+	"dz":       "dz-Tibt-BT",
+	"ee":       "ee-Latn-GH",
+	"el":       "el-Grek-GR",
+	"en":       "en-Latn-US",
+	"eo":       "eo-Latn-001",
+	"es":       "es-Latn-ES",
+	"et":       "et-Latn-EE",
+	"eu":       "eu-Latn-ES",
+	"fa":       "fa-Arab-IR",
+	"ff":       "ff-Latn-SN",
+	"fi":       "fi-Latn-FI",
+	"fil":      "fil-Latn-PH",
+	"fo":       "fo-Latn-FO",
+	"fr":       "fr-Latn-FR",
+	"fur":      "fur-Latn-IT",
+	"fy":       "fy-Latn-NL",
+	"ga":       "ga-Latn-IE",
+	"gd":       "gd-Latn-GB",
+	"gl":       "gl-Latn-ES",
+	"gsw":      "gsw-Latn-CH",
+	"gu":       "gu-Gujr-IN",
+	"gv":       "gv-Latn-IM",
+	"ha":       "ha-Latn-NG",
+	"ha-Arab":  "ha-Arab-NG",
+	"ha-CM":    "ha-Arab-CM",
+	"ha-SD":    "ha-Arab-SD",
+	"haw":      "haw-Latn-US",
+	"he":       "he-Hebr-IL",
+	"hi":       "hi-Deva-IN",
+	"hr":       "hr-Latn-HR",
+	"hsb":      "hsb-Latn-DE",
+	"hu":       "hu-Latn-HU",
+	"hy":       "hy-Armn-AM",
+	"id":       "id-Latn-ID",
+	"ig":       "ig-Latn-NG",
+	"ii":       "ii-Yiii-CN",
+	"in":       "in-Latn-ID",
+	"is":       "is-Latn-IS",
+	"it":       "it-Latn-IT",
+	"iu":       "iu-Cans-CA",
+	"iw":       "iw-Hebr-IL",
+	"ja":       "ja-Jpan-JP",
+	"jbo":      "jbo-Latn-001",
+	"jgo":      "jgo-Latn-CM",
+	"ji":       "ji-Hebr-001",
+	"jmc":      "jmc-Latn-TZ",
+	"jv":       "jv-Latn-ID",
+	"jw":       "jw-Latn-ID",
+	"ka":       "ka-Geor-GE",
+	"kab":      "kab-Latn-DZ",
+	"kaj":      "kaj-Latn-NG",
+	"kcg":      "kcg-Latn-NG",
+	"kde":      "kde-Latn-TZ",
+	"kea":      "kea-Latn-CV",
+	"kk":       "kk-Cyrl-KZ",
+	"kk-AF":    "kk-Arab-AF",
+	"kk-Arab":  "kk-Arab-CN",
+	"kk-CN":    "kk-Arab-CN",
+	"kk-IR":    "kk-Arab-IR",
+	"kk-MN":    "kk-Arab-MN",
+	"kkj":      "kkj-Latn-CM",
+	"kl":       "kl-Latn-GL",
+	"km":       "km-Khmr-KH",
+	"kn":       "kn-Knda-IN",
+	"ko":       "ko-Kore-KR",
+	"ks":       "ks-Arab-IN",
+	"ksb":      "ksb-Latn-TZ",
+	"ksh":      "ksh-Latn-DE",
+	"ku":       "ku-Latn-TR",
+	"ku-Arab":  "ku-Arab-IQ",
+	"ku-LB":    "ku-Arab-LB",
+	"kw":       "kw-Latn-GB",
+	"ky":       "ky-Cyrl-KG",
+	"ky-Arab":  "ky-Arab-CN",
+	"ky-CN":    "ky-Arab-CN",
+	"ky-Latn":  "ky-Latn-TR",
+	"ky-TR":    "ky-Latn-TR",
+	"lag":      "lag-Latn-TZ",
+	"lb":       "lb-Latn-LU",
+	"lg":       "lg-Latn-UG",
+	"lkt":      "lkt-Latn-US",
+	"ln":       "ln-Latn-CD",
+	"lo":       "lo-Laoo-LA",
+	"lt":       "lt-Latn-LT",
+	"lv":       "lv-Latn-LV",
+	"mas":      "mas-Latn-KE",
+	"mg":       "mg-Latn-MG",
+	"mgo":      "mgo-Latn-CM",
+	"mk":       "mk-Cyrl-MK",
+	"ml":       "ml-Mlym-IN",
+	"mn":       "mn-Cyrl-MN",
+	"mn-CN":    "mn-Mong-CN",
+	"mn-Mong":  "mn-Mong-CN",
+	"mo":       "mo-Latn-MD",
+	"mr":       "mr-Deva-IN",
+	"ms":       "ms-Latn-MY",
+	"ms-Arab":  "ms-Arab-MY",
+	"ms-CC":    "ms-Arab-CC",
+	"ms-ID":    "ms-Arab-ID",
+	"mt":       "mt-Latn-MT",
+	"my":       "my-Mymr-MM",
+	"naq":      "naq-Latn-NA",
+	"nb":       "nb-Latn-NO",
+	"nd":       "nd-Latn-ZW",
+	"ne":       "ne-Deva-NP",
+	"nl":       "nl-Latn-NL",
+	"nn":       "nn-Latn-NO",
+	"nnh":      "nnh-Latn-CM",
+	"no":       "no-Latn-NO",
+	"nqo":      "nqo-Nkoo-GN",
+	"nr":       "nr-Latn-ZA",
+	"nso":      "nso-Latn-ZA",
+	"ny":       "ny-Latn-MW",
+	"nyn":      "nyn-Latn-UG",
+	"om":       "om-Latn-ET",
+	"or":       "or-Orya-IN",
+	"os":       "os-Cyrl-GE",
+	"pa":       "pa-Guru-IN",
+	"pa-Arab":  "pa-Arab-PK",
+	"pa-PK":    "pa-Arab-PK",
+	"pap":      "pap-Latn-AW",
+	"pl":       "pl-Latn-PL",
+	"prg":      "prg-Latn-001",
+	"ps":       "ps-Arab-AF",
+	"pt":       "pt-Latn-BR",
+	"rm":       "rm-Latn-CH",
+	"ro":       "ro-Latn-RO",
+	"rof":      "rof-Latn-TZ",
+	"ru":       "ru-Cyrl-RU",
+	"rwk":      "rwk-Latn-TZ",
+	"sah":      "sah-Cyrl-RU",
+	"saq":      "saq-Latn-KE",
+	"sdh":      "sdh-Arab-IR",
+	"se":       "se-Latn-NO",
+	"seh":      "seh-Latn-MZ",
+	"ses":      "ses-Latn-ML",
+	"sg":       "sg-Latn-CF",
+	"sh":       "sh-Latn-RS",
+	"shi":      "shi-Tfng-MA",
+	"si":       "si-Sinh-LK",
+	"sk":       "sk-Latn-SK",
+	"sl":       "sl-Latn-SI",
+	"sma":      "sma-Latn-SE",
+	"smj":      "smj-Latn-SE",
+	"smn":      "smn-Latn-FI",
+	"sms":      "sms-Latn-FI",
+	"sn":       "sn-Latn-ZW",
+	"so":       "so-Latn-SO",
+	"sq":       "sq-Latn-AL",
+	"sr":       "sr-Cyrl-RS",
+	"sr-Latn":  "sr-Latn-RS",
+	"sr-ME":    "sr-Latn-ME",
+	"sr-RO":    "sr-Latn-RO",
+	"sr-RU":    "sr-Latn-RU",
+	"sr-TR":    "sr-Latn-TR",
+	"ss":       "ss-Latn-ZA",
+	"ssy":      "ssy-Latn-ER",
+	"st":       "st-Latn-ZA",
+	"sv":       "sv-Latn-SE",
+	"sw":       "sw-Latn-TZ",
+	"syr":      "syr-Syrc-IQ",
+	"ta":       "ta-Taml-IN",
+	"te":       "te-Telu-IN",
+	"teo":      "teo-Latn-UG",
+	"th":       "th-Thai-TH",
+	"ti":       "ti-Ethi-ET",
+	"tig":      "tig-Ethi-ER",
+	"tk":       "tk-Latn-TM",
+	"tl":       "tl-Latn-PH",
+	"tn":       "tn-Latn-ZA",
+	"to":       "to-Latn-TO",
+	"tr":       "tr-Latn-TR",
+	"ts":       "ts-Latn-ZA",
+	"tzm":      "tzm-Latn-MA",
+	"ug":       "ug-Arab-CN",
+	"ug-Cyrl":  "ug-Cyrl-KZ",
+	"ug-KZ":    "ug-Cyrl-KZ",
+	"ug-MN":    "ug-Cyrl-MN",
+	"uk":       "uk-Cyrl-UA",
+	"ur":       "ur-Arab-PK",
+	"uz":       "uz-Latn-UZ",
+	"uz-AF":    "uz-Arab-AF",
+	"uz-Arab":  "uz-Arab-AF",
+	"uz-CN":    "uz-Cyrl-CN",
+	"uz-Cyrl":  "uz-Cyrl-UZ",
+	"ve":       "ve-Latn-ZA",
+	"vi":       "vi-Latn-VN",
+	"vo":       "vo-Latn-001",
+	"vun":      "vun-Latn-TZ",
+	"wa":       "wa-Latn-BE",
+	"wae":      "wae-Latn-CH",
+	"wo":       "wo-Latn-SN",
+	"xh":       "xh-Latn-ZA",
+	"xog":      "xog-Latn-UG",
+	"yi":       "yi-Hebr-001",
+	"yo":       "yo-Latn-NG",
+	"yue":      "yue-Hant-HK",
+	"yue-CN":   "yue-Hans-CN",
+	"yue-Hans": "yue-Hans-CN",
+	"zh":       "zh-Hans-CN",
+	"zh-419":   "zh-Hant-419",
+	"zh-AU":    "zh-Hant-AU",
+	"zh-BN":    "zh-Hant-BN",
+	"zh-GB":    "zh-Hant-GB",
+	"zh-GF":    "zh-Hant-GF",
+	"zh-HK":    "zh-Hant-HK",
+	"zh-Hant":  "zh-Hant-TW",
+	"zh-ID":    "zh-Hant-ID",
+	"zh-MO":    "zh-Hant-MO",
+	"zh-MY":    "zh-Hant-MY",
+	"zh-PA":    "zh-Hant-PA",
+	"zh-PF":    "zh-Hant-PF",
+	"zh-PH":    "zh-Hant-PH",
+	"zh-SR":    "zh-Hant-SR",
+	"zh-TH":    "zh-Hant-TH",
+	"zh-TW":    "zh-Hant-TW",
+	"zh-US":    "zh-Hant-US",
+	"zh-VN":    "zh-Hant-VN",
+	"zu":       "zu-Latn-ZA",
+}
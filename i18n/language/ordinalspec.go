@@ -0,0 +1,61 @@
+package language
+
+import "strings"
+
+// OrdinalSpec defines the CLDR ordinal plural rules for a language,
+// which choose between forms like "1st", "2nd", "3rd" and "4th" in English.
+// http://www.unicode.org/cldr/charts/latest/supplemental/language_plural_rules.html
+type OrdinalSpec struct {
+	Plurals    map[Plural]struct{}
+	PluralFunc func(*Operands) Plural
+}
+
+var ordinalSpecs = make(map[string]*OrdinalSpec)
+
+// otherOrdinalSpec is the OrdinalSpec of languages that CLDR does not define ordinal rules for.
+var otherOrdinalSpec = &OrdinalSpec{
+	Plurals: newPluralSet(Other),
+	PluralFunc: func(ops *Operands) Plural {
+		return Other
+	},
+}
+
+// RegisterOrdinalSpec registers a new ordinal spec for the language ids.
+func RegisterOrdinalSpec(ids []string, os *OrdinalSpec) {
+	for _, id := range ids {
+		id = normalizePluralSpecID(id)
+		ordinalSpecs[id] = os
+	}
+}
+
+// Plural returns the ordinal plural category for number as defined by
+// the language's CLDR ordinal rules.
+func (os *OrdinalSpec) Plural(number interface{}) (Plural, error) {
+	ops, err := newOperands(number)
+	if err != nil {
+		return Invalid, err
+	}
+	return os.PluralFunc(ops), nil
+}
+
+// SortedPlurals returns the ordinal plural categories of os in CLDR order (zero, one, two, few, many, other).
+func (os *OrdinalSpec) SortedPlurals() []Plural {
+	return sortPlurals(os.Plurals)
+}
+
+// GetOrdinalSpec returns the OrdinalSpec that matches the longest prefix of tag.
+// It returns nil if no OrdinalSpec matches tag.
+func GetOrdinalSpec(tag string) *OrdinalSpec {
+	tag = NormalizeTag(tag)
+	subtag := tag
+	for {
+		if spec := ordinalSpecs[subtag]; spec != nil {
+			return spec
+		}
+		end := strings.LastIndex(subtag, "-")
+		if end == -1 {
+			return nil
+		}
+		subtag = subtag[:end]
+	}
+}
@@ -0,0 +1,321 @@
+package language
+
+// This file is generated by i18n/language/codegen/generate.sh
+
+func init() {
+
+	RegisterOrdinalSpec([]string{"af", "am", "ar", "bg", "bs", "ce", "cs", "da", "de", "dsb", "el", "es", "et", "eu", "fa", "fi", "fy", "gl", "gsw", "he", "hr", "hsb", "id", "in", "is", "iw", "ja", "km", "kn", "ko", "ky", "lt", "lv", "ml", "mn", "my", "nb", "nl", "pa", "pl", "prg", "ps", "pt", "root", "ru", "sd", "sh", "si", "sk", "sl", "sr", "sw", "ta", "te", "th", "tr", "ur", "uz", "yue", "zh", "zu"}, &OrdinalSpec{
+		Plurals: newPluralSet(Other),
+		PluralFunc: func(ops *Operands) Plural {
+			return Other
+		},
+	})
+	RegisterOrdinalSpec([]string{"sv"}, &OrdinalSpec{
+		Plurals: newPluralSet(One, Other),
+		PluralFunc: func(ops *Operands) Plural {
+			// n % 10 = 1,2 and n % 100 != 11,12
+			if ops.NmodEqualsAny(10, 1, 2) && !ops.NmodEqualsAny(100, 11, 12) {
+				return One
+			}
+			return Other
+		},
+	})
+	RegisterOrdinalSpec([]string{"fil", "fr", "ga", "hy", "lo", "mo", "ms", "ro", "tl", "vi"}, &OrdinalSpec{
+		Plurals: newPluralSet(One, Other),
+		PluralFunc: func(ops *Operands) Plural {
+			// n = 1
+			if ops.NequalsAny(1) {
+				return One
+			}
+			return Other
+		},
+	})
+	RegisterOrdinalSpec([]string{"hu"}, &OrdinalSpec{
+		Plurals: newPluralSet(One, Other),
+		PluralFunc: func(ops *Operands) Plural {
+			// n = 1,5
+			if ops.NequalsAny(1, 5) {
+				return One
+			}
+			return Other
+		},
+	})
+	RegisterOrdinalSpec([]string{"ne"}, &OrdinalSpec{
+		Plurals: newPluralSet(One, Other),
+		PluralFunc: func(ops *Operands) Plural {
+			// n = 1..4
+			if ops.NinRange(1, 4) {
+				return One
+			}
+			return Other
+		},
+	})
+	RegisterOrdinalSpec([]string{"be"}, &OrdinalSpec{
+		Plurals: newPluralSet(Few, Other),
+		PluralFunc: func(ops *Operands) Plural {
+			// n % 10 = 2,3 and n % 100 != 12,13
+			if ops.NmodEqualsAny(10, 2, 3) && !ops.NmodEqualsAny(100, 12, 13) {
+				return Few
+			}
+			return Other
+		},
+	})
+	RegisterOrdinalSpec([]string{"uk"}, &OrdinalSpec{
+		Plurals: newPluralSet(Few, Other),
+		PluralFunc: func(ops *Operands) Plural {
+			// n % 10 = 3 and n % 100 != 13
+			if ops.NmodEqualsAny(10, 3) && !ops.NmodEqualsAny(100, 13) {
+				return Few
+			}
+			return Other
+		},
+	})
+	RegisterOrdinalSpec([]string{"tk"}, &OrdinalSpec{
+		Plurals: newPluralSet(Few, Other),
+		PluralFunc: func(ops *Operands) Plural {
+			// n % 10 = 6,9 or n = 10
+			if ops.NmodEqualsAny(10, 6, 9) ||
+				ops.NequalsAny(10) {
+				return Few
+			}
+			return Other
+		},
+	})
+	RegisterOrdinalSpec([]string{"kk"}, &OrdinalSpec{
+		Plurals: newPluralSet(Many, Other),
+		PluralFunc: func(ops *Operands) Plural {
+			// n % 10 = 6 or n % 10 = 9 or n % 10 = 0 and n != 0
+			if ops.NmodEqualsAny(10, 6) ||
+				ops.NmodEqualsAny(10, 9) ||
+				ops.NmodEqualsAny(10, 0) && !ops.NequalsAny(0) {
+				return Many
+			}
+			return Other
+		},
+	})
+	RegisterOrdinalSpec([]string{"it"}, &OrdinalSpec{
+		Plurals: newPluralSet(Many, Other),
+		PluralFunc: func(ops *Operands) Plural {
+			// n = 11,8,80,800
+			if ops.NequalsAny(11, 8, 80, 800) {
+				return Many
+			}
+			return Other
+		},
+	})
+	RegisterOrdinalSpec([]string{"ka"}, &OrdinalSpec{
+		Plurals: newPluralSet(One, Many, Other),
+		PluralFunc: func(ops *Operands) Plural {
+			// i = 1
+			if intEqualsAny(ops.I, 1) {
+				return One
+			}
+			// i = 0 or i % 100 = 2..20,40,60,80
+			if intEqualsAny(ops.I, 0) ||
+				(intInRange(ops.I%100, 2, 20) || intEqualsAny(ops.I%100, 40, 60, 80)) {
+				return Many
+			}
+			return Other
+		},
+	})
+	RegisterOrdinalSpec([]string{"sq"}, &OrdinalSpec{
+		Plurals: newPluralSet(One, Many, Other),
+		PluralFunc: func(ops *Operands) Plural {
+			// n = 1
+			if ops.NequalsAny(1) {
+				return One
+			}
+			// n % 10 = 4 and n % 100 != 14
+			if ops.NmodEqualsAny(10, 4) && !ops.NmodEqualsAny(100, 14) {
+				return Many
+			}
+			return Other
+		},
+	})
+	RegisterOrdinalSpec([]string{"en"}, &OrdinalSpec{
+		Plurals: newPluralSet(One, Two, Few, Other),
+		PluralFunc: func(ops *Operands) Plural {
+			// n % 10 = 1 and n % 100 != 11
+			if ops.NmodEqualsAny(10, 1) && !ops.NmodEqualsAny(100, 11) {
+				return One
+			}
+			// n % 10 = 2 and n % 100 != 12
+			if ops.NmodEqualsAny(10, 2) && !ops.NmodEqualsAny(100, 12) {
+				return Two
+			}
+			// n % 10 = 3 and n % 100 != 13
+			if ops.NmodEqualsAny(10, 3) && !ops.NmodEqualsAny(100, 13) {
+				return Few
+			}
+			return Other
+		},
+	})
+	RegisterOrdinalSpec([]string{"mr"}, &OrdinalSpec{
+		Plurals: newPluralSet(One, Two, Few, Other),
+		PluralFunc: func(ops *Operands) Plural {
+			// n = 1
+			if ops.NequalsAny(1) {
+				return One
+			}
+			// n = 2,3
+			if ops.NequalsAny(2, 3) {
+				return Two
+			}
+			// n = 4
+			if ops.NequalsAny(4) {
+				return Few
+			}
+			return Other
+		},
+	})
+	RegisterOrdinalSpec([]string{"ca"}, &OrdinalSpec{
+		Plurals: newPluralSet(One, Two, Few, Other),
+		PluralFunc: func(ops *Operands) Plural {
+			// n = 1,3
+			if ops.NequalsAny(1, 3) {
+				return One
+			}
+			// n = 2
+			if ops.NequalsAny(2) {
+				return Two
+			}
+			// n = 4
+			if ops.NequalsAny(4) {
+				return Few
+			}
+			return Other
+		},
+	})
+	RegisterOrdinalSpec([]string{"mk"}, &OrdinalSpec{
+		Plurals: newPluralSet(One, Two, Many, Other),
+		PluralFunc: func(ops *Operands) Plural {
+			// i % 10 = 1 and i % 100 != 11
+			if intEqualsAny(ops.I%10, 1) && !intEqualsAny(ops.I%100, 11) {
+				return One
+			}
+			// i % 10 = 2 and i % 100 != 12
+			if intEqualsAny(ops.I%10, 2) && !intEqualsAny(ops.I%100, 12) {
+				return Two
+			}
+			// i % 10 = 7,8 and i % 100 != 17,18
+			if intEqualsAny(ops.I%10, 7, 8) && !intEqualsAny(ops.I%100, 17, 18) {
+				return Many
+			}
+			return Other
+		},
+	})
+	RegisterOrdinalSpec([]string{"az"}, &OrdinalSpec{
+		Plurals: newPluralSet(One, Few, Many, Other),
+		PluralFunc: func(ops *Operands) Plural {
+			// i % 10 = 1,2,5,7,8 or i % 100 = 20,50,70,80
+			if intEqualsAny(ops.I%10, 1, 2, 5, 7, 8) ||
+				intEqualsAny(ops.I%100, 20, 50, 70, 80) {
+				return One
+			}
+			// i % 10 = 3,4 or i % 1000 = 100,200,300,400,500,600,700,800,900
+			if intEqualsAny(ops.I%10, 3, 4) ||
+				intEqualsAny(ops.I%1000, 100, 200, 300, 400, 500, 600, 700, 800, 900) {
+				return Few
+			}
+			// i = 0 or i % 10 = 6 or i % 100 = 40,60,90
+			if intEqualsAny(ops.I, 0) ||
+				intEqualsAny(ops.I%10, 6) ||
+				intEqualsAny(ops.I%100, 40, 60, 90) {
+				return Many
+			}
+			return Other
+		},
+	})
+	RegisterOrdinalSpec([]string{"gu", "hi"}, &OrdinalSpec{
+		Plurals: newPluralSet(One, Two, Few, Many, Other),
+		PluralFunc: func(ops *Operands) Plural {
+			// n = 1
+			if ops.NequalsAny(1) {
+				return One
+			}
+			// n = 2,3
+			if ops.NequalsAny(2, 3) {
+				return Two
+			}
+			// n = 4
+			if ops.NequalsAny(4) {
+				return Few
+			}
+			// n = 6
+			if ops.NequalsAny(6) {
+				return Many
+			}
+			return Other
+		},
+	})
+	RegisterOrdinalSpec([]string{"as", "bn"}, &OrdinalSpec{
+		Plurals: newPluralSet(One, Two, Few, Many, Other),
+		PluralFunc: func(ops *Operands) Plural {
+			// n = 1,5,7,8,9,10
+			if ops.NequalsAny(1, 5, 7, 8, 9, 10) {
+				return One
+			}
+			// n = 2,3
+			if ops.NequalsAny(2, 3) {
+				return Two
+			}
+			// n = 4
+			if ops.NequalsAny(4) {
+				return Few
+			}
+			// n = 6
+			if ops.NequalsAny(6) {
+				return Many
+			}
+			return Other
+		},
+	})
+	RegisterOrdinalSpec([]string{"or"}, &OrdinalSpec{
+		Plurals: newPluralSet(One, Two, Few, Many, Other),
+		PluralFunc: func(ops *Operands) Plural {
+			// n = 1,5,7..9
+			if ops.NinRange(7, 9) || ops.NequalsAny(1, 5) {
+				return One
+			}
+			// n = 2,3
+			if ops.NequalsAny(2, 3) {
+				return Two
+			}
+			// n = 4
+			if ops.NequalsAny(4) {
+				return Few
+			}
+			// n = 6
+			if ops.NequalsAny(6) {
+				return Many
+			}
+			return Other
+		},
+	})
+	RegisterOrdinalSpec([]string{"cy"}, &OrdinalSpec{
+		Plurals: newPluralSet(Zero, One, Two, Few, Many, Other),
+		PluralFunc: func(ops *Operands) Plural {
+			// n = 0,7,8,9
+			if ops.NequalsAny(0, 7, 8, 9) {
+				return Zero
+			}
+			// n = 1
+			if ops.NequalsAny(1) {
+				return One
+			}
+			// n = 2
+			if ops.NequalsAny(2) {
+				return Two
+			}
+			// n = 3,4
+			if ops.NequalsAny(3, 4) {
+				return Few
+			}
+			// n = 5,6
+			if ops.NequalsAny(5, 6) {
+				return Many
+			}
+			return Other
+		},
+	})
+}
@@ -0,0 +1,326 @@
+package language
+
+// This file is generated by i18n/language/codegen/generate.sh
+
+import "testing"
+
+func TestOrdinalAfAmArBgBsCeCsDaDeDsbElEsEtEuFaFiFyGlGswHeHrHsbIdInIsIwJaKmKnKoKyLtLvMlMnMyNbNlPaPlPrgPsPtRootRuSdShSiSkSlSrSwTaTeThTrUrUzYueZhZu(t *testing.T) {
+	var tests []pluralTest
+
+	tests = appendIntegerTests(tests, Other, []string{"0~15", "100", "1000", "10000", "100000", "1000000"})
+
+	locales := []string{"af", "am", "ar", "bg", "bs", "ce", "cs", "da", "de", "dsb", "el", "es", "et", "eu", "fa", "fi", "fy", "gl", "gsw", "he", "hr", "hsb", "id", "in", "is", "iw", "ja", "km", "kn", "ko", "ky", "lt", "lv", "ml", "mn", "my", "nb", "nl", "pa", "pl", "prg", "ps", "pt", "root", "ru", "sd", "sh", "si", "sk", "sl", "sr", "sw", "ta", "te", "th", "tr", "ur", "uz", "yue", "zh", "zu"}
+	for _, locale := range locales {
+		runOrdinalTests(t, locale, tests)
+	}
+}
+
+func TestOrdinalSv(t *testing.T) {
+	var tests []pluralTest
+
+	tests = appendIntegerTests(tests, One, []string{"1", "2", "21", "22", "31", "32", "41", "42", "51", "52", "61", "62", "71", "72", "81", "82", "101", "1001"})
+
+	tests = appendIntegerTests(tests, Other, []string{"0", "3~17", "100", "1000", "10000", "100000", "1000000"})
+
+	locales := []string{"sv"}
+	for _, locale := range locales {
+		runOrdinalTests(t, locale, tests)
+	}
+}
+
+func TestOrdinalFilFrGaHyLoMoMsRoTlVi(t *testing.T) {
+	var tests []pluralTest
+
+	tests = appendIntegerTests(tests, One, []string{"1"})
+
+	tests = appendIntegerTests(tests, Other, []string{"0", "2~16", "100", "1000", "10000", "100000", "1000000"})
+
+	locales := []string{"fil", "fr", "ga", "hy", "lo", "mo", "ms", "ro", "tl", "vi"}
+	for _, locale := range locales {
+		runOrdinalTests(t, locale, tests)
+	}
+}
+
+func TestOrdinalHu(t *testing.T) {
+	var tests []pluralTest
+
+	tests = appendIntegerTests(tests, One, []string{"1", "5"})
+
+	tests = appendIntegerTests(tests, Other, []string{"0", "2~4", "6~17", "100", "1000", "10000", "100000", "1000000"})
+
+	locales := []string{"hu"}
+	for _, locale := range locales {
+		runOrdinalTests(t, locale, tests)
+	}
+}
+
+func TestOrdinalNe(t *testing.T) {
+	var tests []pluralTest
+
+	tests = appendIntegerTests(tests, One, []string{"1~4"})
+
+	tests = appendIntegerTests(tests, Other, []string{"0", "5~19", "100", "1000", "10000", "100000", "1000000"})
+
+	locales := []string{"ne"}
+	for _, locale := range locales {
+		runOrdinalTests(t, locale, tests)
+	}
+}
+
+func TestOrdinalBe(t *testing.T) {
+	var tests []pluralTest
+
+	tests = appendIntegerTests(tests, Few, []string{"2", "3", "22", "23", "32", "33", "42", "43", "52", "53", "62", "63", "72", "73", "82", "83", "102", "1002"})
+
+	tests = appendIntegerTests(tests, Other, []string{"0", "1", "4~17", "100", "1000", "10000", "100000", "1000000"})
+
+	locales := []string{"be"}
+	for _, locale := range locales {
+		runOrdinalTests(t, locale, tests)
+	}
+}
+
+func TestOrdinalUk(t *testing.T) {
+	var tests []pluralTest
+
+	tests = appendIntegerTests(tests, Few, []string{"3", "23", "33", "43", "53", "63", "73", "83", "103", "1003"})
+
+	tests = appendIntegerTests(tests, Other, []string{"0~2", "4~16", "100", "1000", "10000", "100000", "1000000"})
+
+	locales := []string{"uk"}
+	for _, locale := range locales {
+		runOrdinalTests(t, locale, tests)
+	}
+}
+
+func TestOrdinalTk(t *testing.T) {
+	var tests []pluralTest
+
+	tests = appendIntegerTests(tests, Few, []string{"6", "9", "10", "16", "19", "26", "29", "36", "39", "106", "1006"})
+
+	tests = appendIntegerTests(tests, Other, []string{"0~5", "7", "8", "11~15", "17", "18", "20", "100", "1000", "10000", "100000", "1000000"})
+
+	locales := []string{"tk"}
+	for _, locale := range locales {
+		runOrdinalTests(t, locale, tests)
+	}
+}
+
+func TestOrdinalKk(t *testing.T) {
+	var tests []pluralTest
+
+	tests = appendIntegerTests(tests, Many, []string{"6", "9", "10", "16", "19", "20", "26", "29", "30", "36", "39", "40", "100", "1000", "10000", "100000", "1000000"})
+
+	tests = appendIntegerTests(tests, Other, []string{"0~5", "7", "8", "11~15", "17", "18", "21", "101", "1001"})
+
+	locales := []string{"kk"}
+	for _, locale := range locales {
+		runOrdinalTests(t, locale, tests)
+	}
+}
+
+func TestOrdinalIt(t *testing.T) {
+	var tests []pluralTest
+
+	tests = appendIntegerTests(tests, Many, []string{"8", "11", "80", "800"})
+
+	tests = appendIntegerTests(tests, Other, []string{"0~7", "9", "10", "12~17", "100", "1000", "10000", "100000", "1000000"})
+
+	locales := []string{"it"}
+	for _, locale := range locales {
+		runOrdinalTests(t, locale, tests)
+	}
+}
+
+func TestOrdinalKa(t *testing.T) {
+	var tests []pluralTest
+
+	tests = appendIntegerTests(tests, One, []string{"1"})
+
+	tests = appendIntegerTests(tests, Many, []string{"0", "2~16", "102", "1002"})
+
+	tests = appendIntegerTests(tests, Other, []string{"21~36", "100", "1000", "10000", "100000", "1000000"})
+
+	locales := []string{"ka"}
+	for _, locale := range locales {
+		runOrdinalTests(t, locale, tests)
+	}
+}
+
+func TestOrdinalSq(t *testing.T) {
+	var tests []pluralTest
+
+	tests = appendIntegerTests(tests, One, []string{"1"})
+
+	tests = appendIntegerTests(tests, Many, []string{"4", "24", "34", "44", "54", "64", "74", "84", "104", "1004"})
+
+	tests = appendIntegerTests(tests, Other, []string{"0", "2", "3", "5~17", "100", "1000", "10000", "100000", "1000000"})
+
+	locales := []string{"sq"}
+	for _, locale := range locales {
+		runOrdinalTests(t, locale, tests)
+	}
+}
+
+func TestOrdinalEn(t *testing.T) {
+	var tests []pluralTest
+
+	tests = appendIntegerTests(tests, One, []string{"1", "21", "31", "41", "51", "61", "71", "81", "101", "1001"})
+
+	tests = appendIntegerTests(tests, Two, []string{"2", "22", "32", "42", "52", "62", "72", "82", "102", "1002"})
+
+	tests = appendIntegerTests(tests, Few, []string{"3", "23", "33", "43", "53", "63", "73", "83", "103", "1003"})
+
+	tests = appendIntegerTests(tests, Other, []string{"0", "4~18", "100", "1000", "10000", "100000", "1000000"})
+
+	locales := []string{"en"}
+	for _, locale := range locales {
+		runOrdinalTests(t, locale, tests)
+	}
+}
+
+func TestOrdinalMr(t *testing.T) {
+	var tests []pluralTest
+
+	tests = appendIntegerTests(tests, One, []string{"1"})
+
+	tests = appendIntegerTests(tests, Two, []string{"2", "3"})
+
+	tests = appendIntegerTests(tests, Few, []string{"4"})
+
+	tests = appendIntegerTests(tests, Other, []string{"0", "5~19", "100", "1000", "10000", "100000", "1000000"})
+
+	locales := []string{"mr"}
+	for _, locale := range locales {
+		runOrdinalTests(t, locale, tests)
+	}
+}
+
+func TestOrdinalCa(t *testing.T) {
+	var tests []pluralTest
+
+	tests = appendIntegerTests(tests, One, []string{"1", "3"})
+
+	tests = appendIntegerTests(tests, Two, []string{"2"})
+
+	tests = appendIntegerTests(tests, Few, []string{"4"})
+
+	tests = appendIntegerTests(tests, Other, []string{"0", "5~19", "100", "1000", "10000", "100000", "1000000"})
+
+	locales := []string{"ca"}
+	for _, locale := range locales {
+		runOrdinalTests(t, locale, tests)
+	}
+}
+
+func TestOrdinalMk(t *testing.T) {
+	var tests []pluralTest
+
+	tests = appendIntegerTests(tests, One, []string{"1", "21", "31", "41", "51", "61", "71", "81", "101", "1001"})
+
+	tests = appendIntegerTests(tests, Two, []string{"2", "22", "32", "42", "52", "62", "72", "82", "102", "1002"})
+
+	tests = appendIntegerTests(tests, Many, []string{"7", "8", "27", "28", "37", "38", "47", "48", "57", "58", "67", "68", "77", "78", "87", "88", "107", "1007"})
+
+	tests = appendIntegerTests(tests, Other, []string{"0", "3~6", "9~19", "100", "1000", "10000", "100000", "1000000"})
+
+	locales := []string{"mk"}
+	for _, locale := range locales {
+		runOrdinalTests(t, locale, tests)
+	}
+}
+
+func TestOrdinalAz(t *testing.T) {
+	var tests []pluralTest
+
+	tests = appendIntegerTests(tests, One, []string{"1", "2", "5", "7", "8", "11", "12", "15", "17", "18", "20~22", "25", "101", "1001"})
+
+	tests = appendIntegerTests(tests, Few, []string{"3", "4", "13", "14", "23", "24", "33", "34", "43", "44", "53", "54", "63", "64", "73", "74", "100", "1003"})
+
+	tests = appendIntegerTests(tests, Many, []string{"0", "6", "16", "26", "36", "40", "46", "56", "106", "1006"})
+
+	tests = appendIntegerTests(tests, Other, []string{"9", "10", "19", "29", "30", "39", "49", "59", "69", "79", "109", "1000", "10000", "100000", "1000000"})
+
+	locales := []string{"az"}
+	for _, locale := range locales {
+		runOrdinalTests(t, locale, tests)
+	}
+}
+
+func TestOrdinalGuHi(t *testing.T) {
+	var tests []pluralTest
+
+	tests = appendIntegerTests(tests, One, []string{"1"})
+
+	tests = appendIntegerTests(tests, Two, []string{"2", "3"})
+
+	tests = appendIntegerTests(tests, Few, []string{"4"})
+
+	tests = appendIntegerTests(tests, Many, []string{"6"})
+
+	tests = appendIntegerTests(tests, Other, []string{"0", "5", "7~20", "100", "1000", "10000", "100000", "1000000"})
+
+	locales := []string{"gu", "hi"}
+	for _, locale := range locales {
+		runOrdinalTests(t, locale, tests)
+	}
+}
+
+func TestOrdinalAsBn(t *testing.T) {
+	var tests []pluralTest
+
+	tests = appendIntegerTests(tests, One, []string{"1", "5", "7~10"})
+
+	tests = appendIntegerTests(tests, Two, []string{"2", "3"})
+
+	tests = appendIntegerTests(tests, Few, []string{"4"})
+
+	tests = appendIntegerTests(tests, Many, []string{"6"})
+
+	tests = appendIntegerTests(tests, Other, []string{"0", "11~25", "100", "1000", "10000", "100000", "1000000"})
+
+	locales := []string{"as", "bn"}
+	for _, locale := range locales {
+		runOrdinalTests(t, locale, tests)
+	}
+}
+
+func TestOrdinalOr(t *testing.T) {
+	var tests []pluralTest
+
+	tests = appendIntegerTests(tests, One, []string{"1", "5", "7~9"})
+
+	tests = appendIntegerTests(tests, Two, []string{"2", "3"})
+
+	tests = appendIntegerTests(tests, Few, []string{"4"})
+
+	tests = appendIntegerTests(tests, Many, []string{"6"})
+
+	tests = appendIntegerTests(tests, Other, []string{"0", "10~24", "100", "1000", "10000", "100000", "1000000"})
+
+	locales := []string{"or"}
+	for _, locale := range locales {
+		runOrdinalTests(t, locale, tests)
+	}
+}
+
+func TestOrdinalCy(t *testing.T) {
+	var tests []pluralTest
+
+	tests = appendIntegerTests(tests, Zero, []string{"0", "7~9"})
+
+	tests = appendIntegerTests(tests, One, []string{"1"})
+
+	tests = appendIntegerTests(tests, Two, []string{"2"})
+
+	tests = appendIntegerTests(tests, Few, []string{"3", "4"})
+
+	tests = appendIntegerTests(tests, Many, []string{"5", "6"})
+
+	tests = appendIntegerTests(tests, Other, []string{"10~25", "100", "1000", "10000", "100000", "1000000"})
+
+	locales := []string{"cy"}
+	for _, locale := range locales {
+		runOrdinalTests(t, locale, tests)
+	}
+}
@@ -0,0 +1,72 @@
+package language
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetOrdinalSpec(t *testing.T) {
+	tests := []struct {
+		src  string
+		spec *OrdinalSpec
+	}{
+		{"en", ordinalSpecs["en"]},
+		{"en-US", ordinalSpecs["en"]},
+		{"en_GB", ordinalSpecs["en"]},
+		{"fr-CA", ordinalSpecs["fr"]},
+		{"zh-Hant-TW", ordinalSpecs["zh"]},
+		{"ak", nil},
+		{"xx", nil},
+		{"", nil},
+	}
+	for _, test := range tests {
+		if spec := GetOrdinalSpec(test.src); spec != test.spec {
+			t.Errorf("GetOrdinalSpec(%q) = %v expected %v", test.src, spec, test.spec)
+		}
+	}
+}
+
+func TestLanguageOrdinal(t *testing.T) {
+	tests := []struct {
+		tag     string
+		num     interface{}
+		ordinal Plural
+	}{
+		{"en-US", 1, One},
+		{"en-US", 2, Two},
+		{"en-US", "3", Few},
+		{"en-US", 4, Other},
+		{"en-US", 11, Other},
+		{"en-US", 21, One},
+		{"fr", 1, One},
+		{"fr", 2, Other},
+		{"cy", 0, Zero},
+		// CLDR does not define ordinal rules for Akan.
+		{"ak", 1, Other},
+	}
+	for _, test := range tests {
+		lang := &Language{Tag: test.tag, PluralSpec: GetPluralSpec(test.tag)}
+		if ordinal, err := lang.Ordinal(test.num); ordinal != test.ordinal || err != nil {
+			t.Errorf("%s: Ordinal(%#v) returned %s, %v; expected %s", test.tag, test.num, ordinal, err, test.ordinal)
+		}
+	}
+}
+
+func TestOrdinalSortedPlurals(t *testing.T) {
+	if actual, expected := GetOrdinalSpec("en").SortedPlurals(), []Plural{One, Two, Few, Other}; !reflect.DeepEqual(actual, expected) {
+		t.Errorf("SortedPlurals() for en = %v expected %v", actual, expected)
+	}
+}
+
+func runOrdinalTests(t *testing.T, ordinalSpecID string, tests []pluralTest) {
+	ordinalSpecID = normalizePluralSpecID(ordinalSpecID)
+	if spec := ordinalSpecs[ordinalSpecID]; spec != nil {
+		for _, test := range tests {
+			if plural, err := spec.Plural(test.num); plural != test.plural {
+				t.Errorf("%s: Ordinal(%#v) returned %s, %v; expected %s", ordinalSpecID, test.num, plural, err, test.plural)
+			}
+		}
+	} else {
+		t.Errorf("could not find ordinal spec for locale %s", ordinalSpecID)
+	}
+}
@@ -8,10 +8,22 @@ import "strings"
 type PluralSpec struct {
 	Plurals    map[Plural]struct{}
 	PluralFunc func(*Operands) Plural
+
+	// Ranges maps the plural categories of the start and end of a range
+	// to the plural category of the range as defined by CLDR plural ranges.
+	// http://unicode.org/reports/tr35/tr35-numbers.html#Plural_Ranges
+	Ranges map[[2]Plural]Plural
+
+	// GettextPlural is the plural expression of the Plural-Forms header of gettext PO files in C syntax,
+	// which returns the index of the plural category of an integer n in SortedPlurals.
+	GettextPlural string
 }
 
 var pluralSpecs = make(map[string]*PluralSpec)
 
+// pluralOrder is the order in which CLDR lists plural categories.
+var pluralOrder = []Plural{Zero, One, Two, Few, Many, Other}
+
 func normalizePluralSpecID(id string) string {
 	id = strings.Replace(id, "_", "-", -1)
 	id = strings.ToLower(id)
@@ -26,6 +38,28 @@ func RegisterPluralSpec(ids []string, ps *PluralSpec) {
 	}
 }
 
+// RegisterPluralRanges registers the plural ranges for the language ids.
+//
+// The plural spec of each id must already be registered.
+// Because languages that share plural rules can have different plural ranges,
+// each plural spec is copied before ranges are added to it.
+func RegisterPluralRanges(ids []string, ranges map[[2]Plural]Plural) {
+	copies := make(map[*PluralSpec]*PluralSpec)
+	for _, id := range ids {
+		id = normalizePluralSpecID(id)
+		ps := pluralSpecs[id]
+		if ps == nil {
+			continue
+		}
+		if copies[ps] == nil {
+			c := *ps
+			c.Ranges = ranges
+			copies[ps] = &c
+		}
+		pluralSpecs[id] = copies[ps]
+	}
+}
+
 // Plural returns the plural category for number as defined by
 // the language's CLDR plural rules.
 func (ps *PluralSpec) Plural(number interface{}) (Plural, error) {
@@ -36,6 +70,34 @@ func (ps *PluralSpec) Plural(number interface{}) (Plural, error) {
 	return ps.PluralFunc(ops), nil
 }
 
+// PluralRange returns the plural category of a range of numbers
+// whose start and end have the plural categories start and end (e.g. "1–3 days" is Other in English).
+//
+// If the language does not define the plural category of the range, it is end.
+func (ps *PluralSpec) PluralRange(start, end Plural) Plural {
+	if p, ok := ps.Ranges[[2]Plural{start, end}]; ok {
+		return p
+	}
+	return end
+}
+
+// SortedPlurals returns the plural categories of ps in CLDR order (zero, one, two, few, many, other).
+//
+// This is also the order of the msgstr[n] forms in gettext PO files.
+func (ps *PluralSpec) SortedPlurals() []Plural {
+	return sortPlurals(ps.Plurals)
+}
+
+func sortPlurals(set map[Plural]struct{}) []Plural {
+	plurals := make([]Plural, 0, len(set))
+	for _, p := range pluralOrder {
+		if _, ok := set[p]; ok {
+			plurals = append(plurals, p)
+		}
+	}
+	return plurals
+}
+
 // GetPluralSpec returns the PluralSpec that matches the longest prefix of tag.
 // It returns nil if no PluralSpec matches tag.
 func GetPluralSpec(tag string) *PluralSpec {
@@ -9,6 +9,7 @@ func init() {
 		PluralFunc: func(ops *Operands) Plural {
 			return Other
 		},
+		GettextPlural: "0",
 	})
 	RegisterPluralSpec([]string{"am", "as", "bn", "fa", "gu", "hi", "kn", "mr", "zu"}, &PluralSpec{
 		Plurals: newPluralSet(One, Other),
@@ -20,6 +21,7 @@ func init() {
 			}
 			return Other
 		},
+		GettextPlural: "n == 0 || n == 1 ? 0 : 1",
 	})
 	RegisterPluralSpec([]string{"ff", "fr", "hy", "kab"}, &PluralSpec{
 		Plurals: newPluralSet(One, Other),
@@ -30,6 +32,7 @@ func init() {
 			}
 			return Other
 		},
+		GettextPlural: "(n == 0 || n == 1) ? 0 : 1",
 	})
 	RegisterPluralSpec([]string{"pt"}, &PluralSpec{
 		Plurals: newPluralSet(One, Other),
@@ -40,6 +43,7 @@ func init() {
 			}
 			return Other
 		},
+		GettextPlural: "(n >= 0 && n <= 1) ? 0 : 1",
 	})
 	RegisterPluralSpec([]string{"ast", "ca", "de", "en", "et", "fi", "fy", "gl", "it", "ji", "nl", "sv", "sw", "ur", "yi"}, &PluralSpec{
 		Plurals: newPluralSet(One, Other),
@@ -50,6 +54,7 @@ func init() {
 			}
 			return Other
 		},
+		GettextPlural: "n == 1 ? 0 : 1",
 	})
 	RegisterPluralSpec([]string{"si"}, &PluralSpec{
 		Plurals: newPluralSet(One, Other),
@@ -61,6 +66,7 @@ func init() {
 			}
 			return Other
 		},
+		GettextPlural: "(n == 0 || n == 1) ? 0 : 1",
 	})
 	RegisterPluralSpec([]string{"ak", "bh", "guw", "ln", "mg", "nso", "pa", "ti", "wa"}, &PluralSpec{
 		Plurals: newPluralSet(One, Other),
@@ -71,6 +77,7 @@ func init() {
 			}
 			return Other
 		},
+		GettextPlural: "(n >= 0 && n <= 1) ? 0 : 1",
 	})
 	RegisterPluralSpec([]string{"tzm"}, &PluralSpec{
 		Plurals: newPluralSet(One, Other),
@@ -82,6 +89,7 @@ func init() {
 			}
 			return Other
 		},
+		GettextPlural: "(n >= 0 && n <= 1) || (n >= 11 && n <= 99) ? 0 : 1",
 	})
 	RegisterPluralSpec([]string{"af", "asa", "az", "bem", "bez", "bg", "brx", "ce", "cgg", "chr", "ckb", "dv", "ee", "el", "eo", "es", "eu", "fo", "fur", "gsw", "ha", "haw", "hu", "jgo", "jmc", "ka", "kaj", "kcg", "kk", "kkj", "kl", "ks", "ksb", "ku", "ky", "lb", "lg", "mas", "mgo", "ml", "mn", "nah", "nb", "nd", "ne", "nn", "nnh", "no", "nr", "ny", "nyn", "om", "or", "os", "pap", "ps", "rm", "rof", "rwk", "saq", "sdh", "seh", "sn", "so", "sq", "ss", "ssy", "st", "syr", "ta", "te", "teo", "tig", "tk", "tn", "tr", "ts", "ug", "uz", "ve", "vo", "vun", "wae", "xh", "xog"}, &PluralSpec{
 		Plurals: newPluralSet(One, Other),
@@ -92,6 +100,7 @@ func init() {
 			}
 			return Other
 		},
+		GettextPlural: "n == 1 ? 0 : 1",
 	})
 	RegisterPluralSpec([]string{"da"}, &PluralSpec{
 		Plurals: newPluralSet(One, Other),
@@ -103,6 +112,7 @@ func init() {
 			}
 			return Other
 		},
+		GettextPlural: "n == 1 ? 0 : 1",
 	})
 	RegisterPluralSpec([]string{"is"}, &PluralSpec{
 		Plurals: newPluralSet(One, Other),
@@ -114,6 +124,7 @@ func init() {
 			}
 			return Other
 		},
+		GettextPlural: "(n % 10 == 1 && n % 100 != 11) ? 0 : 1",
 	})
 	RegisterPluralSpec([]string{"mk"}, &PluralSpec{
 		Plurals: newPluralSet(One, Other),
@@ -125,6 +136,7 @@ func init() {
 			}
 			return Other
 		},
+		GettextPlural: "n % 10 == 1 ? 0 : 1",
 	})
 	RegisterPluralSpec([]string{"fil", "tl"}, &PluralSpec{
 		Plurals: newPluralSet(One, Other),
@@ -137,6 +149,7 @@ func init() {
 			}
 			return Other
 		},
+		GettextPlural: "(n == 1 || n == 2 || n == 3) || !(n % 10 == 4 || n % 10 == 6 || n % 10 == 9) ? 0 : 1",
 	})
 	RegisterPluralSpec([]string{"lv", "prg"}, &PluralSpec{
 		Plurals: newPluralSet(Zero, One, Other),
@@ -155,6 +168,7 @@ func init() {
 			}
 			return Other
 		},
+		GettextPlural: "n % 10 == 0 || (n % 100 >= 11 && n % 100 <= 19) ? 0 : (n % 10 == 1 && n % 100 != 11) ? 1 : 2",
 	})
 	RegisterPluralSpec([]string{"lag"}, &PluralSpec{
 		Plurals: newPluralSet(Zero, One, Other),
@@ -169,6 +183,7 @@ func init() {
 			}
 			return Other
 		},
+		GettextPlural: "n == 0 ? 0 : (n == 0 || n == 1) && n != 0 ? 1 : 2",
 	})
 	RegisterPluralSpec([]string{"ksh"}, &PluralSpec{
 		Plurals: newPluralSet(Zero, One, Other),
@@ -183,6 +198,7 @@ func init() {
 			}
 			return Other
 		},
+		GettextPlural: "n == 0 ? 0 : n == 1 ? 1 : 2",
 	})
 	RegisterPluralSpec([]string{"iu", "kw", "naq", "se", "sma", "smi", "smj", "smn", "sms"}, &PluralSpec{
 		Plurals: newPluralSet(One, Two, Other),
@@ -197,6 +213,7 @@ func init() {
 			}
 			return Other
 		},
+		GettextPlural: "n == 1 ? 0 : n == 2 ? 1 : 2",
 	})
 	RegisterPluralSpec([]string{"shi"}, &PluralSpec{
 		Plurals: newPluralSet(One, Few, Other),
@@ -212,6 +229,7 @@ func init() {
 			}
 			return Other
 		},
+		GettextPlural: "n == 0 || n == 1 ? 0 : (n >= 2 && n <= 10) ? 1 : 2",
 	})
 	RegisterPluralSpec([]string{"mo", "ro"}, &PluralSpec{
 		Plurals: newPluralSet(One, Few, Other),
@@ -228,6 +246,7 @@ func init() {
 			}
 			return Other
 		},
+		GettextPlural: "n == 1 ? 0 : n == 0 || (n != 1 && (n % 100 >= 1 && n % 100 <= 19)) ? 1 : 2",
 	})
 	RegisterPluralSpec([]string{"bs", "hr", "sh", "sr"}, &PluralSpec{
 		Plurals: newPluralSet(One, Few, Other),
@@ -244,6 +263,7 @@ func init() {
 			}
 			return Other
 		},
+		GettextPlural: "(n % 10 == 1 && n % 100 != 11) ? 0 : ((n % 10 >= 2 && n % 10 <= 4) && !(n % 100 >= 12 && n % 100 <= 14)) ? 1 : 2",
 	})
 	RegisterPluralSpec([]string{"gd"}, &PluralSpec{
 		Plurals: newPluralSet(One, Two, Few, Other),
@@ -262,6 +282,7 @@ func init() {
 			}
 			return Other
 		},
+		GettextPlural: "(n == 1 || n == 11) ? 0 : (n == 2 || n == 12) ? 1 : (n >= 3 && n <= 10 || n >= 13 && n <= 19) ? 2 : 3",
 	})
 	RegisterPluralSpec([]string{"sl"}, &PluralSpec{
 		Plurals: newPluralSet(One, Two, Few, Other),
@@ -281,6 +302,7 @@ func init() {
 			}
 			return Other
 		},
+		GettextPlural: "n % 100 == 1 ? 0 : n % 100 == 2 ? 1 : (n % 100 >= 3 && n % 100 <= 4) ? 2 : 3",
 	})
 	RegisterPluralSpec([]string{"dsb", "hsb"}, &PluralSpec{
 		Plurals: newPluralSet(One, Two, Few, Other),
@@ -302,6 +324,7 @@ func init() {
 			}
 			return Other
 		},
+		GettextPlural: "n % 100 == 1 ? 0 : n % 100 == 2 ? 1 : (n % 100 >= 3 && n % 100 <= 4) ? 2 : 3",
 	})
 	RegisterPluralSpec([]string{"he", "iw"}, &PluralSpec{
 		Plurals: newPluralSet(One, Two, Many, Other),
@@ -320,6 +343,7 @@ func init() {
 			}
 			return Other
 		},
+		GettextPlural: "n == 1 ? 0 : n == 2 ? 1 : !(n >= 0 && n <= 10) && n % 10 == 0 ? 2 : 3",
 	})
 	RegisterPluralSpec([]string{"cs", "sk"}, &PluralSpec{
 		Plurals: newPluralSet(One, Few, Many, Other),
@@ -338,6 +362,7 @@ func init() {
 			}
 			return Other
 		},
+		GettextPlural: "n == 1 ? 0 : (n >= 2 && n <= 4) ? 1 : 3",
 	})
 	RegisterPluralSpec([]string{"pl"}, &PluralSpec{
 		Plurals: newPluralSet(One, Few, Many, Other),
@@ -358,6 +383,7 @@ func init() {
 			}
 			return Other
 		},
+		GettextPlural: "n == 1 ? 0 : (n % 10 >= 2 && n % 10 <= 4) && !(n % 100 >= 12 && n % 100 <= 14) ? 1 : (n != 1 && (n % 10 >= 0 && n % 10 <= 1)) || (n % 10 >= 5 && n % 10 <= 9) || (n % 100 >= 12 && n % 100 <= 14) ? 2 : 3",
 	})
 	RegisterPluralSpec([]string{"be"}, &PluralSpec{
 		Plurals: newPluralSet(One, Few, Many, Other),
@@ -378,6 +404,7 @@ func init() {
 			}
 			return Other
 		},
+		GettextPlural: "n % 10 == 1 && n % 100 != 11 ? 0 : (n % 10 >= 2 && n % 10 <= 4) && !(n % 100 >= 12 && n % 100 <= 14) ? 1 : n % 10 == 0 || (n % 10 >= 5 && n % 10 <= 9) || (n % 100 >= 11 && n % 100 <= 14) ? 2 : 3",
 	})
 	RegisterPluralSpec([]string{"lt"}, &PluralSpec{
 		Plurals: newPluralSet(One, Few, Many, Other),
@@ -396,6 +423,7 @@ func init() {
 			}
 			return Other
 		},
+		GettextPlural: "n % 10 == 1 && !(n % 100 >= 11 && n % 100 <= 19) ? 0 : (n % 10 >= 2 && n % 10 <= 9) && !(n % 100 >= 11 && n % 100 <= 19) ? 1 : 3",
 	})
 	RegisterPluralSpec([]string{"mt"}, &PluralSpec{
 		Plurals: newPluralSet(One, Few, Many, Other),
@@ -415,6 +443,7 @@ func init() {
 			}
 			return Other
 		},
+		GettextPlural: "n == 1 ? 0 : n == 0 || (n % 100 >= 2 && n % 100 <= 10) ? 1 : (n % 100 >= 11 && n % 100 <= 19) ? 2 : 3",
 	})
 	RegisterPluralSpec([]string{"ru", "uk"}, &PluralSpec{
 		Plurals: newPluralSet(One, Few, Many, Other),
@@ -435,6 +464,7 @@ func init() {
 			}
 			return Other
 		},
+		GettextPlural: "n % 10 == 1 && n % 100 != 11 ? 0 : (n % 10 >= 2 && n % 10 <= 4) && !(n % 100 >= 12 && n % 100 <= 14) ? 1 : n % 10 == 0 || (n % 10 >= 5 && n % 10 <= 9) || (n % 100 >= 11 && n % 100 <= 14) ? 2 : 3",
 	})
 	RegisterPluralSpec([]string{"br"}, &PluralSpec{
 		Plurals: newPluralSet(One, Two, Few, Many, Other),
@@ -457,6 +487,7 @@ func init() {
 			}
 			return Other
 		},
+		GettextPlural: "n % 10 == 1 && !(n % 100 == 11 || n % 100 == 71 || n % 100 == 91) ? 0 : n % 10 == 2 && !(n % 100 == 12 || n % 100 == 72 || n % 100 == 92) ? 1 : (n % 10 >= 3 && n % 10 <= 4 || n % 10 == 9) && !(n % 100 >= 10 && n % 100 <= 19 || n % 100 >= 70 && n % 100 <= 79 || n % 100 >= 90 && n % 100 <= 99) ? 2 : n != 0 && n % 1000000 == 0 ? 3 : 4",
 	})
 	RegisterPluralSpec([]string{"ga"}, &PluralSpec{
 		Plurals: newPluralSet(One, Two, Few, Many, Other),
@@ -479,6 +510,7 @@ func init() {
 			}
 			return Other
 		},
+		GettextPlural: "n == 1 ? 0 : n == 2 ? 1 : (n >= 3 && n <= 6) ? 2 : (n >= 7 && n <= 10) ? 3 : 4",
 	})
 	RegisterPluralSpec([]string{"gv"}, &PluralSpec{
 		Plurals: newPluralSet(One, Two, Few, Many, Other),
@@ -501,6 +533,7 @@ func init() {
 			}
 			return Other
 		},
+		GettextPlural: "n % 10 == 1 ? 0 : n % 10 == 2 ? 1 : (n % 100 == 0 || n % 100 == 20 || n % 100 == 40 || n % 100 == 60 || n % 100 == 80) ? 2 : 4",
 	})
 	RegisterPluralSpec([]string{"ar", "ars"}, &PluralSpec{
 		Plurals: newPluralSet(Zero, One, Two, Few, Many, Other),
@@ -527,6 +560,7 @@ func init() {
 			}
 			return Other
 		},
+		GettextPlural: "n == 0 ? 0 : n == 1 ? 1 : n == 2 ? 2 : (n % 100 >= 3 && n % 100 <= 10) ? 3 : (n % 100 >= 11 && n % 100 <= 99) ? 4 : 5",
 	})
 	RegisterPluralSpec([]string{"cy"}, &PluralSpec{
 		Plurals: newPluralSet(Zero, One, Two, Few, Many, Other),
@@ -553,5 +587,215 @@ func init() {
 			}
 			return Other
 		},
+		GettextPlural: "n == 0 ? 0 : n == 1 ? 1 : n == 2 ? 2 : n == 3 ? 3 : n == 6 ? 4 : 5",
+	})
+	RegisterPluralRanges([]string{"id", "ja", "km", "ko", "lo", "ms", "my", "th", "vi", "yue", "zh"}, map[[2]Plural]Plural{
+		{Other, Other}: Other,
+	})
+	RegisterPluralRanges([]string{"af", "bg", "ca", "en", "es", "et", "eu", "fi", "nb", "sv", "ur"}, map[[2]Plural]Plural{
+		{One, Other}:   Other,
+		{Other, One}:   Other,
+		{Other, Other}: Other,
+	})
+	RegisterPluralRanges([]string{"mk"}, map[[2]Plural]Plural{
+		{One, One}:     Other,
+		{One, Other}:   Other,
+		{Other, One}:   Other,
+		{Other, Other}: Other,
+	})
+	RegisterPluralRanges([]string{"ak", "fa", "or"}, map[[2]Plural]Plural{
+		{One, One}:     Other,
+		{One, Other}:   Other,
+		{Other, One}:   One,
+		{Other, Other}: Other,
+	})
+	RegisterPluralRanges([]string{"am", "as", "bn", "fr", "gu", "hi", "hy", "kn", "mr", "ps", "pt", "zu"}, map[[2]Plural]Plural{
+		{One, One}:     One,
+		{One, Other}:   Other,
+		{Other, Other}: Other,
+	})
+	RegisterPluralRanges([]string{"ar"}, map[[2]Plural]Plural{
+		{Zero, One}:    Zero,
+		{Zero, Two}:    Zero,
+		{Zero, Few}:    Few,
+		{Zero, Many}:   Many,
+		{Zero, Other}:  Other,
+		{One, Two}:     Other,
+		{One, Few}:     Few,
+		{One, Many}:    Many,
+		{One, Other}:   Other,
+		{Two, Few}:     Few,
+		{Two, Many}:    Many,
+		{Two, Other}:   Other,
+		{Few, Few}:     Few,
+		{Few, Many}:    Many,
+		{Few, Other}:   Other,
+		{Many, Few}:    Few,
+		{Many, Many}:   Many,
+		{Many, Other}:  Other,
+		{Other, One}:   Other,
+		{Other, Two}:   Other,
+		{Other, Few}:   Few,
+		{Other, Many}:  Many,
+		{Other, Other}: Other,
+	})
+	RegisterPluralRanges([]string{"az", "de", "el", "gl", "gsw", "hu", "it", "kk", "ky", "ml", "mn", "ne", "nl", "sq", "sw", "ta", "te", "tk", "tr", "ug", "uz"}, map[[2]Plural]Plural{
+		{One, Other}:   Other,
+		{Other, One}:   One,
+		{Other, Other}: Other,
+	})
+	RegisterPluralRanges([]string{"be", "lt", "ru", "uk"}, map[[2]Plural]Plural{
+		{One, One}:     One,
+		{One, Few}:     Few,
+		{One, Many}:    Many,
+		{One, Other}:   Other,
+		{Few, One}:     One,
+		{Few, Few}:     Few,
+		{Few, Many}:    Many,
+		{Few, Other}:   Other,
+		{Many, One}:    One,
+		{Many, Few}:    Few,
+		{Many, Many}:   Many,
+		{Many, Other}:  Other,
+		{Other, One}:   One,
+		{Other, Few}:   Few,
+		{Other, Many}:  Many,
+		{Other, Other}: Other,
+	})
+	RegisterPluralRanges([]string{"bs", "hr", "sr"}, map[[2]Plural]Plural{
+		{One, One}:     One,
+		{One, Few}:     Few,
+		{One, Other}:   Other,
+		{Few, One}:     One,
+		{Few, Few}:     Few,
+		{Few, Other}:   Other,
+		{Other, One}:   One,
+		{Other, Few}:   Few,
+		{Other, Other}: Other,
+	})
+	RegisterPluralRanges([]string{"cs", "pl", "sk"}, map[[2]Plural]Plural{
+		{One, Few}:     Few,
+		{One, Many}:    Many,
+		{One, Other}:   Other,
+		{Few, Few}:     Few,
+		{Few, Many}:    Many,
+		{Few, Other}:   Other,
+		{Many, One}:    One,
+		{Many, Few}:    Few,
+		{Many, Many}:   Many,
+		{Many, Other}:  Other,
+		{Other, One}:   One,
+		{Other, Few}:   Few,
+		{Other, Many}:  Many,
+		{Other, Other}: Other,
+	})
+	RegisterPluralRanges([]string{"cy"}, map[[2]Plural]Plural{
+		{Zero, One}:    One,
+		{Zero, Two}:    Two,
+		{Zero, Few}:    Few,
+		{Zero, Many}:   Many,
+		{Zero, Other}:  Other,
+		{One, Two}:     Two,
+		{One, Few}:     Few,
+		{One, Many}:    Many,
+		{One, Other}:   Other,
+		{Two, Few}:     Few,
+		{Two, Many}:    Many,
+		{Two, Other}:   Other,
+		{Few, Many}:    Many,
+		{Few, Other}:   Other,
+		{Many, Other}:  Other,
+		{Other, One}:   One,
+		{Other, Two}:   Two,
+		{Other, Few}:   Few,
+		{Other, Many}:  Many,
+		{Other, Other}: Other,
+	})
+	RegisterPluralRanges([]string{"da", "fil", "is", "pa"}, map[[2]Plural]Plural{
+		{One, One}:     One,
+		{One, Other}:   Other,
+		{Other, One}:   One,
+		{Other, Other}: Other,
+	})
+	RegisterPluralRanges([]string{"ga"}, map[[2]Plural]Plural{
+		{One, Two}:     Two,
+		{One, Few}:     Few,
+		{One, Many}:    Many,
+		{One, Other}:   Other,
+		{Two, Few}:     Few,
+		{Two, Many}:    Many,
+		{Two, Other}:   Other,
+		{Few, Few}:     Few,
+		{Few, Many}:    Many,
+		{Few, Other}:   Other,
+		{Many, Many}:   Many,
+		{Many, Other}:  Other,
+		{Other, One}:   One,
+		{Other, Two}:   Two,
+		{Other, Few}:   Few,
+		{Other, Many}:  Many,
+		{Other, Other}: Other,
+	})
+	RegisterPluralRanges([]string{"he"}, map[[2]Plural]Plural{
+		{One, Two}:     Other,
+		{One, Many}:    Many,
+		{One, Other}:   Other,
+		{Two, Many}:    Other,
+		{Two, Other}:   Other,
+		{Many, Many}:   Many,
+		{Many, Other}:  Many,
+		{Other, One}:   Other,
+		{Other, Two}:   Other,
+		{Other, Many}:  Many,
+		{Other, Other}: Other,
+	})
+	RegisterPluralRanges([]string{"ka"}, map[[2]Plural]Plural{
+		{One, Other}:   One,
+		{Other, One}:   Other,
+		{Other, Other}: Other,
+	})
+	RegisterPluralRanges([]string{"lv"}, map[[2]Plural]Plural{
+		{Zero, Zero}:   Other,
+		{Zero, One}:    One,
+		{Zero, Other}:  Other,
+		{One, Zero}:    Other,
+		{One, One}:     One,
+		{One, Other}:   Other,
+		{Other, Zero}:  Other,
+		{Other, One}:   One,
+		{Other, Other}: Other,
+	})
+	RegisterPluralRanges([]string{"ro"}, map[[2]Plural]Plural{
+		{One, Few}:     Few,
+		{One, Other}:   Other,
+		{Few, One}:     Few,
+		{Few, Few}:     Few,
+		{Few, Other}:   Other,
+		{Other, Few}:   Few,
+		{Other, Other}: Other,
+	})
+	RegisterPluralRanges([]string{"si"}, map[[2]Plural]Plural{
+		{One, One}:     One,
+		{One, Other}:   Other,
+		{Other, One}:   Other,
+		{Other, Other}: Other,
+	})
+	RegisterPluralRanges([]string{"sl"}, map[[2]Plural]Plural{
+		{One, One}:     Few,
+		{One, Two}:     Two,
+		{One, Few}:     Few,
+		{One, Other}:   Other,
+		{Two, One}:     Few,
+		{Two, Two}:     Two,
+		{Two, Few}:     Few,
+		{Two, Other}:   Other,
+		{Few, One}:     Few,
+		{Few, Two}:     Two,
+		{Few, Few}:     Few,
+		{Few, Other}:   Other,
+		{Other, One}:   Few,
+		{Other, Two}:   Two,
+		{Other, Few}:   Few,
+		{Other, Other}: Other,
 	})
 }
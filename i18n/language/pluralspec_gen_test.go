@@ -629,3 +629,345 @@ func TestCy(t *testing.T) {
 		runTests(t, locale, tests)
 	}
 }
+
+func TestPluralRangesIdJaKmKoLoMsMyThViYueZh(t *testing.T) {
+	tests := []pluralRangeTest{
+		{Other, Other, Other},
+	}
+	locales := []string{"id", "ja", "km", "ko", "lo", "ms", "my", "th", "vi", "yue", "zh"}
+	for _, locale := range locales {
+		runPluralRangeTests(t, locale, tests)
+	}
+}
+
+func TestPluralRangesAfBgCaEnEsEtEuFiNbSvUr(t *testing.T) {
+	tests := []pluralRangeTest{
+		{One, Other, Other},
+		{Other, One, Other},
+		{Other, Other, Other},
+	}
+	locales := []string{"af", "bg", "ca", "en", "es", "et", "eu", "fi", "nb", "sv", "ur"}
+	for _, locale := range locales {
+		runPluralRangeTests(t, locale, tests)
+	}
+}
+
+func TestPluralRangesMk(t *testing.T) {
+	tests := []pluralRangeTest{
+		{One, One, Other},
+		{One, Other, Other},
+		{Other, One, Other},
+		{Other, Other, Other},
+	}
+	locales := []string{"mk"}
+	for _, locale := range locales {
+		runPluralRangeTests(t, locale, tests)
+	}
+}
+
+func TestPluralRangesAkFaOr(t *testing.T) {
+	tests := []pluralRangeTest{
+		{One, One, Other},
+		{One, Other, Other},
+		{Other, One, One},
+		{Other, Other, Other},
+	}
+	locales := []string{"ak", "fa", "or"}
+	for _, locale := range locales {
+		runPluralRangeTests(t, locale, tests)
+	}
+}
+
+func TestPluralRangesAmAsBnFrGuHiHyKnMrPsPtZu(t *testing.T) {
+	tests := []pluralRangeTest{
+		{One, One, One},
+		{One, Other, Other},
+		{Other, Other, Other},
+	}
+	locales := []string{"am", "as", "bn", "fr", "gu", "hi", "hy", "kn", "mr", "ps", "pt", "zu"}
+	for _, locale := range locales {
+		runPluralRangeTests(t, locale, tests)
+	}
+}
+
+func TestPluralRangesAr(t *testing.T) {
+	tests := []pluralRangeTest{
+		{Zero, One, Zero},
+		{Zero, Two, Zero},
+		{Zero, Few, Few},
+		{Zero, Many, Many},
+		{Zero, Other, Other},
+		{One, Two, Other},
+		{One, Few, Few},
+		{One, Many, Many},
+		{One, Other, Other},
+		{Two, Few, Few},
+		{Two, Many, Many},
+		{Two, Other, Other},
+		{Few, Few, Few},
+		{Few, Many, Many},
+		{Few, Other, Other},
+		{Many, Few, Few},
+		{Many, Many, Many},
+		{Many, Other, Other},
+		{Other, One, Other},
+		{Other, Two, Other},
+		{Other, Few, Few},
+		{Other, Many, Many},
+		{Other, Other, Other},
+	}
+	locales := []string{"ar"}
+	for _, locale := range locales {
+		runPluralRangeTests(t, locale, tests)
+	}
+}
+
+func TestPluralRangesAzDeElGlGswHuItKkKyMlMnNeNlSqSwTaTeTkTrUgUz(t *testing.T) {
+	tests := []pluralRangeTest{
+		{One, Other, Other},
+		{Other, One, One},
+		{Other, Other, Other},
+	}
+	locales := []string{"az", "de", "el", "gl", "gsw", "hu", "it", "kk", "ky", "ml", "mn", "ne", "nl", "sq", "sw", "ta", "te", "tk", "tr", "ug", "uz"}
+	for _, locale := range locales {
+		runPluralRangeTests(t, locale, tests)
+	}
+}
+
+func TestPluralRangesBeLtRuUk(t *testing.T) {
+	tests := []pluralRangeTest{
+		{One, One, One},
+		{One, Few, Few},
+		{One, Many, Many},
+		{One, Other, Other},
+		{Few, One, One},
+		{Few, Few, Few},
+		{Few, Many, Many},
+		{Few, Other, Other},
+		{Many, One, One},
+		{Many, Few, Few},
+		{Many, Many, Many},
+		{Many, Other, Other},
+		{Other, One, One},
+		{Other, Few, Few},
+		{Other, Many, Many},
+		{Other, Other, Other},
+	}
+	locales := []string{"be", "lt", "ru", "uk"}
+	for _, locale := range locales {
+		runPluralRangeTests(t, locale, tests)
+	}
+}
+
+func TestPluralRangesBsHrSr(t *testing.T) {
+	tests := []pluralRangeTest{
+		{One, One, One},
+		{One, Few, Few},
+		{One, Other, Other},
+		{Few, One, One},
+		{Few, Few, Few},
+		{Few, Other, Other},
+		{Other, One, One},
+		{Other, Few, Few},
+		{Other, Other, Other},
+	}
+	locales := []string{"bs", "hr", "sr"}
+	for _, locale := range locales {
+		runPluralRangeTests(t, locale, tests)
+	}
+}
+
+func TestPluralRangesCsPlSk(t *testing.T) {
+	tests := []pluralRangeTest{
+		{One, Few, Few},
+		{One, Many, Many},
+		{One, Other, Other},
+		{Few, Few, Few},
+		{Few, Many, Many},
+		{Few, Other, Other},
+		{Many, One, One},
+		{Many, Few, Few},
+		{Many, Many, Many},
+		{Many, Other, Other},
+		{Other, One, One},
+		{Other, Few, Few},
+		{Other, Many, Many},
+		{Other, Other, Other},
+	}
+	locales := []string{"cs", "pl", "sk"}
+	for _, locale := range locales {
+		runPluralRangeTests(t, locale, tests)
+	}
+}
+
+func TestPluralRangesCy(t *testing.T) {
+	tests := []pluralRangeTest{
+		{Zero, One, One},
+		{Zero, Two, Two},
+		{Zero, Few, Few},
+		{Zero, Many, Many},
+		{Zero, Other, Other},
+		{One, Two, Two},
+		{One, Few, Few},
+		{One, Many, Many},
+		{One, Other, Other},
+		{Two, Few, Few},
+		{Two, Many, Many},
+		{Two, Other, Other},
+		{Few, Many, Many},
+		{Few, Other, Other},
+		{Many, Other, Other},
+		{Other, One, One},
+		{Other, Two, Two},
+		{Other, Few, Few},
+		{Other, Many, Many},
+		{Other, Other, Other},
+	}
+	locales := []string{"cy"}
+	for _, locale := range locales {
+		runPluralRangeTests(t, locale, tests)
+	}
+}
+
+func TestPluralRangesDaFilIsPa(t *testing.T) {
+	tests := []pluralRangeTest{
+		{One, One, One},
+		{One, Other, Other},
+		{Other, One, One},
+		{Other, Other, Other},
+	}
+	locales := []string{"da", "fil", "is", "pa"}
+	for _, locale := range locales {
+		runPluralRangeTests(t, locale, tests)
+	}
+}
+
+func TestPluralRangesGa(t *testing.T) {
+	tests := []pluralRangeTest{
+		{One, Two, Two},
+		{One, Few, Few},
+		{One, Many, Many},
+		{One, Other, Other},
+		{Two, Few, Few},
+		{Two, Many, Many},
+		{Two, Other, Other},
+		{Few, Few, Few},
+		{Few, Many, Many},
+		{Few, Other, Other},
+		{Many, Many, Many},
+		{Many, Other, Other},
+		{Other, One, One},
+		{Other, Two, Two},
+		{Other, Few, Few},
+		{Other, Many, Many},
+		{Other, Other, Other},
+	}
+	locales := []string{"ga"}
+	for _, locale := range locales {
+		runPluralRangeTests(t, locale, tests)
+	}
+}
+
+func TestPluralRangesHe(t *testing.T) {
+	tests := []pluralRangeTest{
+		{One, Two, Other},
+		{One, Many, Many},
+		{One, Other, Other},
+		{Two, Many, Other},
+		{Two, Other, Other},
+		{Many, Many, Many},
+		{Many, Other, Many},
+		{Other, One, Other},
+		{Other, Two, Other},
+		{Other, Many, Many},
+		{Other, Other, Other},
+	}
+	locales := []string{"he"}
+	for _, locale := range locales {
+		runPluralRangeTests(t, locale, tests)
+	}
+}
+
+func TestPluralRangesKa(t *testing.T) {
+	tests := []pluralRangeTest{
+		{One, Other, One},
+		{Other, One, Other},
+		{Other, Other, Other},
+	}
+	locales := []string{"ka"}
+	for _, locale := range locales {
+		runPluralRangeTests(t, locale, tests)
+	}
+}
+
+func TestPluralRangesLv(t *testing.T) {
+	tests := []pluralRangeTest{
+		{Zero, Zero, Other},
+		{Zero, One, One},
+		{Zero, Other, Other},
+		{One, Zero, Other},
+		{One, One, One},
+		{One, Other, Other},
+		{Other, Zero, Other},
+		{Other, One, One},
+		{Other, Other, Other},
+	}
+	locales := []string{"lv"}
+	for _, locale := range locales {
+		runPluralRangeTests(t, locale, tests)
+	}
+}
+
+func TestPluralRangesRo(t *testing.T) {
+	tests := []pluralRangeTest{
+		{One, Few, Few},
+		{One, Other, Other},
+		{Few, One, Few},
+		{Few, Few, Few},
+		{Few, Other, Other},
+		{Other, Few, Few},
+		{Other, Other, Other},
+	}
+	locales := []string{"ro"}
+	for _, locale := range locales {
+		runPluralRangeTests(t, locale, tests)
+	}
+}
+
+func TestPluralRangesSi(t *testing.T) {
+	tests := []pluralRangeTest{
+		{One, One, One},
+		{One, Other, Other},
+		{Other, One, Other},
+		{Other, Other, Other},
+	}
+	locales := []string{"si"}
+	for _, locale := range locales {
+		runPluralRangeTests(t, locale, tests)
+	}
+}
+
+func TestPluralRangesSl(t *testing.T) {
+	tests := []pluralRangeTest{
+		{One, One, Few},
+		{One, Two, Two},
+		{One, Few, Few},
+		{One, Other, Other},
+		{Two, One, Few},
+		{Two, Two, Two},
+		{Two, Few, Few},
+		{Two, Other, Other},
+		{Few, One, Few},
+		{Few, Two, Two},
+		{Few, Few, Few},
+		{Few, Other, Other},
+		{Other, One, Few},
+		{Other, Two, Two},
+		{Other, Few, Few},
+		{Other, Other, Other},
+	}
+	locales := []string{"sl"}
+	for _, locale := range locales {
+		runPluralRangeTests(t, locale, tests)
+	}
+}
@@ -2,6 +2,8 @@ package language
 
 import (
 	"fmt"
+	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
 	"testing"
@@ -75,6 +77,23 @@ func TestGetPluralSpec(t *testing.T) {
 	}
 }
 
+func TestSortedPlurals(t *testing.T) {
+	tests := []struct {
+		src     string
+		plurals []Plural
+	}{
+		{"ja", []Plural{Other}},
+		{"en", []Plural{One, Other}},
+		{"pl", []Plural{One, Few, Many, Other}},
+		{"ar", []Plural{Zero, One, Two, Few, Many, Other}},
+	}
+	for _, test := range tests {
+		if actual := GetPluralSpec(test.src).SortedPlurals(); !reflect.DeepEqual(actual, test.plurals) {
+			t.Errorf("SortedPlurals() for %q = %v expected %v", test.src, actual, test.plurals)
+		}
+	}
+}
+
 type pluralTest struct {
 	num    interface{}
 	plural Plural
@@ -701,6 +720,50 @@ func appendFloatTests(tests []pluralTest, from, to float64, p Plural) []pluralTe
 	return tests
 }
 
+func TestRangePlural(t *testing.T) {
+	tests := []struct {
+		tag         string
+		numberRange Range
+		plural      Plural
+	}{
+		{"en-US", Range{1, 3}, Other},
+		{"en-US", Range{0, 1}, Other},
+		{"fr", Range{0, 1}, One},
+		{"de", Range{0, 1}, One},
+		{"ru", Range{1, 2}, Few},
+		{"ru", Range{1, "2.5"}, Other},
+		// There are no plural ranges for Hausa, so the range has the plural category of its end.
+		{"ha", Range{0, 1}, One},
+	}
+	for _, test := range tests {
+		lang := &Language{Tag: test.tag, PluralSpec: GetPluralSpec(test.tag)}
+		if plural, err := lang.RangePlural(test.numberRange); plural != test.plural || err != nil {
+			t.Errorf("%s: RangePlural(%v) returned %s, %v; expected %s", test.tag, test.numberRange, plural, err, test.plural)
+		}
+	}
+	lang := &Language{Tag: "en", PluralSpec: GetPluralSpec("en")}
+	if _, err := lang.RangePlural(Range{1, 2.5}); err == nil {
+		t.Errorf("RangePlural(Range{1, 2.5}) returned no error")
+	}
+}
+
+type pluralRangeTest struct {
+	start, end, plural Plural
+}
+
+func runPluralRangeTests(t *testing.T, pluralSpecID string, tests []pluralRangeTest) {
+	pluralSpecID = normalizePluralSpecID(pluralSpecID)
+	if spec := pluralSpecs[pluralSpecID]; spec != nil {
+		for _, test := range tests {
+			if plural := spec.PluralRange(test.start, test.end); plural != test.plural {
+				t.Errorf("%s: PluralRange(%s, %s) returned %s; expected %s", pluralSpecID, test.start, test.end, plural, test.plural)
+			}
+		}
+	} else {
+		t.Errorf("could not find plural spec for locale %s", pluralSpecID)
+	}
+}
+
 func runTests(t *testing.T, pluralSpecID string, tests []pluralTest) {
 	pluralSpecID = normalizePluralSpecID(pluralSpecID)
 	if spec := pluralSpecs[pluralSpecID]; spec != nil {
@@ -714,3 +777,141 @@ func runTests(t *testing.T, pluralSpecID string, tests []pluralTest) {
 	}
 
 }
+
+func TestGettextPlural(t *testing.T) {
+	for id, spec := range pluralSpecs {
+		plurals := spec.SortedPlurals()
+		numbers := []int{1000, 1001, 1000000, 2000000, 1000001}
+		for n := 0; n <= 200; n++ {
+			numbers = append(numbers, n)
+		}
+		for _, n := range numbers {
+			expected, err := spec.Plural(n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			index, err := evalGettextPlural(spec.GettextPlural, n)
+			if err != nil {
+				t.Fatalf("%s: %s", id, err)
+			}
+			if index < 0 || index >= len(plurals) || plurals[index] != expected {
+				t.Errorf("%s: %q = %d for %d; expected the index of %s in %v", id, spec.GettextPlural, index, n, expected, plurals)
+				break
+			}
+		}
+	}
+}
+
+// evalGettextPlural evaluates the C expression of a gettext plural for n.
+func evalGettextPlural(expr string, n int) (int, error) {
+	p := &gettextParser{tokens: gettextTokenRegexp.FindAllString(expr, -1), n: n}
+	v := p.ternary()
+	if p.pos != len(p.tokens) {
+		return 0, fmt.Errorf("unexpected %q in %q", p.tokens[p.pos:], expr)
+	}
+	return v, nil
+}
+
+var gettextTokenRegexp = regexp.MustCompile(`[0-9]+|n|==|!=|>=|<=|&&|\|\||[!%()?:<>]`)
+
+type gettextParser struct {
+	tokens []string
+	pos    int
+	n      int
+}
+
+func (p *gettextParser) accept(token string) bool {
+	if p.pos < len(p.tokens) && p.tokens[p.pos] == token {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *gettextParser) ternary() int {
+	cond := p.or()
+	if !p.accept("?") {
+		return cond
+	}
+	a := p.ternary()
+	p.accept(":")
+	b := p.ternary()
+	if cond != 0 {
+		return a
+	}
+	return b
+}
+
+func (p *gettextParser) or() int {
+	v := p.and()
+	for p.accept("||") {
+		w := p.and()
+		v = boolInt(v != 0 || w != 0)
+	}
+	return v
+}
+
+func (p *gettextParser) and() int {
+	v := p.comparison()
+	for p.accept("&&") {
+		w := p.comparison()
+		v = boolInt(v != 0 && w != 0)
+	}
+	return v
+}
+
+func (p *gettextParser) comparison() int {
+	v := p.mod()
+	for {
+		switch {
+		case p.accept("=="):
+			v = boolInt(v == p.mod())
+		case p.accept("!="):
+			v = boolInt(v != p.mod())
+		case p.accept(">="):
+			v = boolInt(v >= p.mod())
+		case p.accept("<="):
+			v = boolInt(v <= p.mod())
+		case p.accept(">"):
+			v = boolInt(v > p.mod())
+		case p.accept("<"):
+			v = boolInt(v < p.mod())
+		default:
+			return v
+		}
+	}
+}
+
+func (p *gettextParser) mod() int {
+	v := p.unary()
+	for p.accept("%") {
+		v %= p.unary()
+	}
+	return v
+}
+
+func (p *gettextParser) unary() int {
+	switch {
+	case p.accept("!"):
+		return boolInt(p.unary() == 0)
+	case p.accept("("):
+		v := p.ternary()
+		p.accept(")")
+		return v
+	case p.accept("n"):
+		return p.n
+	}
+	if p.pos < len(p.tokens) {
+		p.pos++
+		v, _ := strconv.Atoi(p.tokens[p.pos-1])
+		return v
+	}
+	return 0
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
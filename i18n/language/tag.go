@@ -0,0 +1,223 @@
+package language
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Tag is a BCP 47 language tag (RFC 5646) split into its subtags,
+// in the canonical case of each subtag (e.g. "zh-Hant-TW").
+type Tag struct {
+	// Language is the primary language subtag in lower case (e.g. "zh"),
+	// or "" for the undetermined language "und".
+	Language string
+
+	// Script is the ISO 15924 script subtag in title case (e.g. "Hant").
+	Script string
+
+	// Region is the ISO 3166-1 or UN M.49 region subtag in upper case (e.g. "TW" or "419").
+	Region string
+
+	// Variants are the variant subtags in lower case (e.g. "1901").
+	Variants []string
+
+	// Extensions are the extensions and the private use subtags in lower case,
+	// each starting with its singleton (e.g. "u-ca-buddhist" or "x-pseudo").
+	Extensions []string
+}
+
+// ParseTag parses a BCP 47 language tag.
+// Subtags are case insensitive and may be separated by underscores instead of dashes.
+func ParseTag(s string) (Tag, error) {
+	var t Tag
+	subtags := strings.Split(NormalizeTag(s), "-")
+	lang := subtags[0]
+	if len(lang) < 2 || len(lang) > 8 || len(lang) == 4 || !isAlpha(lang) {
+		return t, fmt.Errorf("invalid language tag %q: invalid language subtag %q", s, lang)
+	}
+	if lang != "und" {
+		t.Language = lang
+	}
+	i := 1
+	if i < len(subtags) && len(subtags[i]) == 4 && isAlpha(subtags[i]) {
+		t.Script = strings.ToUpper(subtags[i][:1]) + subtags[i][1:]
+		i++
+	}
+	if i < len(subtags) && (len(subtags[i]) == 2 && isAlpha(subtags[i]) || len(subtags[i]) == 3 && isDigit(subtags[i])) {
+		t.Region = strings.ToUpper(subtags[i])
+		i++
+	}
+	for i < len(subtags) && isVariant(subtags[i]) {
+		t.Variants = append(t.Variants, subtags[i])
+		i++
+	}
+	for i < len(subtags) {
+		singleton := subtags[i]
+		if len(singleton) != 1 || !isAlphanum(singleton) {
+			return Tag{}, fmt.Errorf("invalid language tag %q: unexpected subtag %q", s, singleton)
+		}
+		// Extension subtags have 2 to 8 characters; private use subtags have 1 to 8 characters and end the tag.
+		j := i + 1
+		for j < len(subtags) && len(subtags[j]) <= 8 && isAlphanum(subtags[j]) && (len(subtags[j]) > 1 || singleton == "x") {
+			j++
+		}
+		if j == i+1 || singleton == "x" && j < len(subtags) {
+			return Tag{}, fmt.Errorf("invalid language tag %q: invalid %q extension", s, singleton)
+		}
+		t.Extensions = append(t.Extensions, strings.Join(subtags[i:j], "-"))
+		i = j
+	}
+	return t, nil
+}
+
+// MustParseTag is similar to ParseTag except it panics if an error happens.
+func MustParseTag(s string) Tag {
+	t, err := ParseTag(s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func (t Tag) String() string {
+	subtags := []string{"und"}
+	if t.Language != "" {
+		subtags[0] = t.Language
+	}
+	if t.Script != "" {
+		subtags = append(subtags, t.Script)
+	}
+	if t.Region != "" {
+		subtags = append(subtags, t.Region)
+	}
+	subtags = append(subtags, t.Variants...)
+	subtags = append(subtags, t.Extensions...)
+	return strings.Join(subtags, "-")
+}
+
+// IsRoot reports whether t is the undetermined language "und" without any other subtags,
+// which is the parent of every language.
+func (t Tag) IsRoot() bool {
+	return t.Language == "" && t.Script == "" && t.Region == "" && len(t.Variants) == 0 && len(t.Extensions) == 0
+}
+
+// Unicode returns the value of the key of the Unicode locale extension of t
+// (e.g. "buddhist" for the key "ca" of "th-u-ca-buddhist"), or "" if t does not have the key.
+func (t Tag) Unicode(key string) string {
+	for _, ext := range t.Extensions {
+		if !strings.HasPrefix(ext, "u-") {
+			continue
+		}
+		subtags := strings.Split(ext, "-")[1:]
+		for i, subtag := range subtags {
+			if subtag != key {
+				continue
+			}
+			// The value of a key is the subtags up to the next key, which has two characters.
+			j := i + 1
+			for j < len(subtags) && len(subtags[j]) > 2 {
+				j++
+			}
+			if j == i+1 {
+				// A key without a value means "true".
+				return "true"
+			}
+			return strings.Join(subtags[i+1:j], "-")
+		}
+	}
+	return ""
+}
+
+// Maximize returns t with its likely script and region added if t does not have them
+// (e.g. "zh-Hant-TW" for "zh-TW" and "sr-Cyrl-RS" for "sr"), as defined by CLDR likely subtags.
+// Only the languages that have plural rules have likely subtags.
+func (t Tag) Maximize() Tag {
+	if t.Language == "" {
+		return t
+	}
+	var keys []string
+	if t.Script != "" && t.Region != "" {
+		keys = append(keys, t.Language+"-"+t.Script+"-"+t.Region)
+	}
+	if t.Region != "" {
+		keys = append(keys, t.Language+"-"+t.Region)
+	}
+	if t.Script != "" {
+		keys = append(keys, t.Language+"-"+t.Script)
+	}
+	keys = append(keys, t.Language)
+	for _, key := range keys {
+		if likely, ok := likelySubtags[key]; ok {
+			lt := MustParseTag(likely)
+			if t.Script == "" {
+				t.Script = lt.Script
+			}
+			if t.Region == "" {
+				t.Region = lt.Region
+			}
+			return t
+		}
+	}
+	return t
+}
+
+// Parent returns the tag whose translations t falls back to, or the root tag if t has no parent.
+//
+// The parent removes the last subtag of t, but it keeps the script of t if the script is not
+// the likely script of the language. For example, the parent of "zh-TW" is "zh-Hant",
+// whose parent is the root tag and not "zh", because the likely script of "zh" is "Hans".
+// Likewise, the parent of "sr-Latn" is the root tag and the parent of "sr-Cyrl" is "sr".
+func (t Tag) Parent() Tag {
+	switch {
+	case len(t.Extensions) > 0:
+		return Tag{Language: t.Language, Script: t.Script, Region: t.Region, Variants: t.Variants}
+	case len(t.Variants) > 0:
+		return Tag{Language: t.Language, Script: t.Script, Region: t.Region, Variants: t.Variants[:len(t.Variants)-1]}
+	case t.Region != "":
+		parent := Tag{Language: t.Language, Script: t.Script}
+		if script := t.Maximize().Script; parent.Script == "" && script != parent.Maximize().Script {
+			parent.Script = script
+		}
+		return parent
+	case t.Script != "":
+		if parent := (Tag{Language: t.Language}); parent.Language != "" && parent.Maximize().Script == t.Script {
+			return parent
+		}
+	}
+	return Tag{}
+}
+
+func isAlpha(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 'a' || s[i] > 'z' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func isDigit(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func isAlphanum(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if (s[i] < 'a' || s[i] > 'z') && (s[i] < '0' || s[i] > '9') {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// isVariant reports whether s is a variant subtag: 5 to 8 characters, or 4 characters starting with a digit.
+func isVariant(s string) bool {
+	if !isAlphanum(s) {
+		return false
+	}
+	return len(s) >= 5 && len(s) <= 8 || len(s) == 4 && isDigit(s[:1])
+}
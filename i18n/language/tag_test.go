@@ -0,0 +1,98 @@
+package language
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTag(t *testing.T) {
+	tests := []struct {
+		src string
+		tag Tag
+	}{
+		{"en", Tag{Language: "en"}},
+		{"und", Tag{}},
+		{"EN_us", Tag{Language: "en", Region: "US"}},
+		{"zh-hant-tw", Tag{Language: "zh", Script: "Hant", Region: "TW"}},
+		{"es-419", Tag{Language: "es", Region: "419"}},
+		{"de-CH-1901", Tag{Language: "de", Region: "CH", Variants: []string{"1901"}}},
+		{"sl-rozaj-biske", Tag{Language: "sl", Variants: []string{"rozaj", "biske"}}},
+		{"th-TH-u-ca-buddhist-nu-thai", Tag{Language: "th", Region: "TH", Extensions: []string{"u-ca-buddhist-nu-thai"}}},
+		{"en-a-bbb-x-a-ccc", Tag{Language: "en", Extensions: []string{"a-bbb", "x-a-ccc"}}},
+	}
+	for _, test := range tests {
+		tag, err := ParseTag(test.src)
+		if err != nil || !reflect.DeepEqual(tag, test.tag) {
+			t.Errorf("ParseTag(%q) = %#v, %v expected %#v, nil", test.src, tag, err, test.tag)
+		}
+	}
+
+	for _, src := range []string{"", "e", "toolonglang", "en--us", "en-u", "en-x", "en-u-a", "en-x-abc-toolongtag", "en-US-1"} {
+		if tag, err := ParseTag(src); err == nil {
+			t.Errorf("ParseTag(%q) = %#v, nil expected an error", src, tag)
+		}
+	}
+}
+
+func TestTagString(t *testing.T) {
+	for _, src := range []string{"und", "en", "zh-Hant-TW", "es-419", "de-CH-1901", "th-TH-u-ca-buddhist-nu-thai", "en-x-pseudo"} {
+		if s := MustParseTag(src).String(); s != src {
+			t.Errorf("MustParseTag(%q).String() = %q", src, s)
+		}
+	}
+}
+
+func TestTagUnicode(t *testing.T) {
+	tag := MustParseTag("th-TH-u-attr-ca-buddhist-nu-thai-va-posix-kn-x-ca-other")
+	tests := map[string]string{"ca": "buddhist", "nu": "thai", "kn": "true", "co": "", "x": ""}
+	for key, expected := range tests {
+		if actual := tag.Unicode(key); actual != expected {
+			t.Errorf("Unicode(%q) = %q expected %q", key, actual, expected)
+		}
+	}
+}
+
+func TestMaximize(t *testing.T) {
+	tests := map[string]string{
+		"en":         "en-Latn-US",
+		"zh":         "zh-Hans-CN",
+		"zh-TW":      "zh-Hant-TW",
+		"zh-Hant":    "zh-Hant-TW",
+		"zh-Hant-CN": "zh-Hant-CN",
+		"sr":         "sr-Cyrl-RS",
+		"sr-Latn":    "sr-Latn-RS",
+		"sr-ME":      "sr-Latn-ME",
+		"pt-PT":      "pt-Latn-PT",
+		"und":        "und",
+		"xx":         "xx",
+	}
+	for src, expected := range tests {
+		if actual := MustParseTag(src).Maximize().String(); actual != expected {
+			t.Errorf("Maximize(%q) = %q expected %q", src, actual, expected)
+		}
+	}
+}
+
+func TestParent(t *testing.T) {
+	tests := map[string]string{
+		"en-US":          "en",
+		"en":             "und",
+		"und":            "und",
+		"zh-TW":          "zh-Hant",
+		"zh-Hant":        "und",
+		"zh-Hans":        "zh",
+		"zh-Hans-CN":     "zh-Hans",
+		"sr-Latn":        "und",
+		"sr-Cyrl":        "sr",
+		"sr-ME":          "sr-Latn",
+		"es-419":         "es",
+		"de-CH-1901":     "de-CH",
+		"en-US-u-ca-iso": "en-US",
+		"xx-YY":          "xx",
+	}
+	for src, expected := range tests {
+		if actual := MustParseTag(src).Parent().String(); actual != expected {
+			t.Errorf("Parent(%q) = %q expected %q", src, actual, expected)
+		}
+	}
+}
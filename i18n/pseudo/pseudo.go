@@ -0,0 +1,397 @@
+// Package pseudo pseudo-localizes translations.
+//
+// Pseudo-localized translations are readable by speakers of the source language,
+// but they look translated, so hard-coded strings stand out and layouts can be checked
+// for longer and right-to-left text before the real translations are available.
+//
+// Importing the package registers the plural and ordinal rules of English for the en-XA and ar-XB
+// pseudo-locales with package language (see language.RegisterPluralSpec), so the pseudo-localized
+// translation files that goi18n pseudo writes can be loaded. Package bundle imports it, so every program
+// that uses go-i18n has these rules. A program that registers other rules for these tags overrides them.
+package pseudo
+
+import (
+	"bytes"
+	"strings"
+	"unicode"
+
+	"github.com/nicksnyder/go-i18n/i18n/language"
+	"github.com/nicksnyder/go-i18n/i18n/translation"
+)
+
+// Method is a way of pseudo-localizing messages.
+type Method int
+
+const (
+	// Accented replaces letters with accented letters and makes messages about 40% longer,
+	// between brackets (e.g. "[Ĥéļļö {{.Person}} one two]"), which shows truncated messages.
+	// It is the method of the en-XA pseudo-locale.
+	Accented Method = iota
+
+	// Bidi makes every word display right-to-left with Unicode bidirectional control characters,
+	// which shows the layout of right-to-left languages with readable text.
+	// It is the method of the ar-XB pseudo-locale.
+	Bidi
+)
+
+// init registers the plural rules of the pseudo-locales (see the package documentation).
+func init() {
+	// Like on Android, the pseudo-locales pseudo-localize English, so they have the plural rules of English.
+	tags := []string{Accented.Tag(), Bidi.Tag()}
+	language.RegisterPluralSpec(tags, language.GetPluralSpec("en"))
+	language.RegisterOrdinalSpec(tags, language.GetOrdinalSpec("en"))
+}
+
+// Tag returns the tag of the pseudo-locale of m.
+func (m Method) Tag() string {
+	if m == Bidi {
+		return "ar-XB"
+	}
+	return "en-XA"
+}
+
+// MethodOf returns the Method of the pseudo-locale tag (e.g. "en-XA" or "ar-XB").
+func MethodOf(tag string) (Method, bool) {
+	switch language.NormalizeTag(tag) {
+	case "en-xa":
+		return Accented, true
+	case "ar-xb":
+		return Bidi, true
+	}
+	return 0, false
+}
+
+// Translation returns a copy of t whose templates are pseudo-localized by m.
+// The templates of HTML translations (see translation.Metadata) are pseudo-localized with HTMLTemplate.
+func Translation(t translation.Translation, m Method) (translation.Translation, error) {
+	if t.Metadata().HTML {
+		return translation.MapTemplates(t, m.HTMLTemplate)
+	}
+	return translation.MapTemplates(t, m.Template)
+}
+
+// Template returns the pseudo-localized template src of syntax (see translation.GoTemplateSyntax and translation.ICUSyntax).
+//
+// Only the text of src is pseudo-localized: text/template actions like {{.Count}}
+// and ICU MessageFormat arguments like {Count, plural, one {# file} other {# files}} stay intact,
+// although the text of the variants of ICU plural and select arguments is pseudo-localized.
+func (m Method) Template(src, syntax string) string {
+	return m.template(src, syntax, false)
+}
+
+// HTMLTemplate is similar to Template except the HTML tags (e.g. <b>)
+// and character references (e.g. &amp;) of src stay intact.
+func (m Method) HTMLTemplate(src, syntax string) string {
+	return m.template(src, syntax, true)
+}
+
+func (m Method) template(src, syntax string, html bool) string {
+	if src == "" {
+		return src
+	}
+	var b bytes.Buffer
+	length := 0
+	text := func(s string) {
+		length += len([]rune(s))
+		b.WriteString(m.text(s))
+	}
+	code := func(s string) {
+		b.WriteString(s)
+	}
+	if html {
+		text = scanHTML(text, code)
+	}
+	if syntax == translation.ICUSyntax {
+		scanICU(src, text, code)
+	} else {
+		scanGoTemplate(src, text, code)
+	}
+	if m == Accented {
+		return "[" + b.String() + expansion(length) + "]"
+	}
+	return b.String()
+}
+
+func (m Method) text(s string) string {
+	if m == Bidi {
+		return bidi(s)
+	}
+	return accent(s)
+}
+
+var accents = map[rune]rune{
+	'A': 'Å', 'B': 'ß', 'C': 'Ç', 'D': 'Ð', 'E': 'É', 'F': 'Ƒ', 'G': 'Ĝ', 'H': 'Ĥ', 'I': 'Î', 'J': 'Ĵ',
+	'K': 'Ķ', 'L': 'Ļ', 'M': 'Ṁ', 'N': 'Ñ', 'O': 'Ö', 'P': 'Þ', 'Q': 'Ǫ', 'R': 'Ŕ', 'S': 'Š', 'T': 'Ţ',
+	'U': 'Û', 'V': 'Ṽ', 'W': 'Ŵ', 'X': 'Ẋ', 'Y': 'Ý', 'Z': 'Ž',
+	'a': 'å', 'b': 'ƀ', 'c': 'ç', 'd': 'ð', 'e': 'é', 'f': 'ƒ', 'g': 'ĝ', 'h': 'ĥ', 'i': 'î', 'j': 'ĵ',
+	'k': 'ķ', 'l': 'ļ', 'm': 'ɱ', 'n': 'ñ', 'o': 'ö', 'p': 'þ', 'q': 'ǫ', 'r': 'ŕ', 's': 'š', 't': 'ţ',
+	'u': 'û', 'v': 'ṽ', 'w': 'ŵ', 'x': 'ẋ', 'y': 'ý', 'z': 'ž',
+}
+
+func accent(s string) string {
+	return strings.Map(func(r rune) rune {
+		if a, ok := accents[r]; ok {
+			return a
+		}
+		return r
+	}, s)
+}
+
+// expansionWords pad accented messages, like the en-XA pseudo-locale of Android.
+var expansionWords = []string{"one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "ten"}
+
+// expansion returns words that make a message with length characters of text about 40% longer.
+func expansion(length int) string {
+	var b bytes.Buffer
+	for i := 0; b.Len() < (length*2+4)/5; i++ {
+		b.WriteString(" ")
+		b.WriteString(expansionWords[i%len(expansionWords)])
+	}
+	return b.String()
+}
+
+const (
+	rlm = "\u200f" // right-to-left mark
+	rlo = "\u202e" // right-to-left override
+	pdf = "\u202c" // pop directional formatting
+)
+
+// bidi makes every word of s display right-to-left.
+func bidi(s string) string {
+	var b bytes.Buffer
+	start := -1
+	for i, r := range s {
+		if unicode.IsSpace(r) {
+			if start >= 0 {
+				b.WriteString(rlm + rlo + s[start:i] + pdf + rlm)
+				start = -1
+			}
+			b.WriteRune(r)
+		} else if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		b.WriteString(rlm + rlo + s[start:] + pdf + rlm)
+	}
+	return b.String()
+}
+
+// scanHTML returns a function that calls text with the text of the HTML it is called with
+// and markup with its tags and character references, in order.
+// A tag may span calls, like a tag with an action in an attribute (e.g. <a href="{{.URL}}">).
+func scanHTML(text, markup func(string)) func(string) {
+	inTag := false
+	return func(s string) {
+		for s != "" {
+			if inTag {
+				i := strings.IndexByte(s, '>')
+				if i < 0 {
+					markup(s)
+					return
+				}
+				markup(s[:i+1])
+				s = s[i+1:]
+				inTag = false
+				continue
+			}
+			i := strings.IndexAny(s, "<&")
+			if i < 0 {
+				text(s)
+				return
+			}
+			if i > 0 {
+				text(s[:i])
+			}
+			s = s[i:]
+			if s[0] == '<' {
+				inTag = true
+				continue
+			}
+			n := charRefLen(s)
+			markup(s[:n])
+			s = s[n:]
+		}
+	}
+}
+
+// charRefLen returns the length of the character reference (e.g. &amp; or &#39;) at the start of s,
+// or 1 if the '&' at the start of s does not start one.
+func charRefLen(s string) int {
+	for i := 1; i < len(s) && i <= 32; i++ {
+		switch c := s[i]; {
+		case c == ';':
+			if i > 1 {
+				return i + 1
+			}
+			return 1
+		case c == '#' && i == 1, 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		default:
+			return 1
+		}
+	}
+	return 1
+}
+
+// scanGoTemplate calls text with the text of the text/template src
+// and action with its actions, in order.
+func scanGoTemplate(src string, text, action func(string)) {
+	for src != "" {
+		i := strings.Index(src, "{{")
+		if i < 0 {
+			text(src)
+			return
+		}
+		if i > 0 {
+			text(src[:i])
+		}
+		n := goTemplateActionLen(src[i:])
+		action(src[i : i+n])
+		src = src[i+n:]
+	}
+}
+
+// goTemplateActionLen returns the length of the action at the start of s,
+// skipping "}}" in the quoted strings of the action.
+func goTemplateActionLen(s string) int {
+	var quote byte
+	for i := 2; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == '\\' && quote != '`' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '`' || c == '\'':
+			quote = c
+		case strings.HasPrefix(s[i:], "}}"):
+			return i + 2
+		}
+	}
+	return len(s)
+}
+
+// scanICU calls text with the text of the ICU MessageFormat message src
+// and argument with the other parts of src, in order.
+// The variant messages of plural, selectordinal and select arguments are scanned as messages.
+func scanICU(src string, text, argument func(string)) {
+	s := &icuScanner{src: src, text: text, argument: argument}
+	s.message(false)
+}
+
+type icuScanner struct {
+	src      string
+	pos      int
+	text     func(string)
+	argument func(string)
+}
+
+// message scans a message until the end of src, or until the '}' that ends it if nested.
+func (s *icuScanner) message(nested bool) {
+	start := s.pos
+	flush := func() {
+		if s.pos > start {
+			s.text(s.src[start:s.pos])
+		}
+	}
+	for s.pos < len(s.src) {
+		switch c := s.src[s.pos]; c {
+		case '\'':
+			s.quoted()
+		case '{':
+			flush()
+			s.arg()
+			start = s.pos
+		case '}':
+			if nested {
+				flush()
+				return
+			}
+			s.pos++
+		case '#':
+			flush()
+			s.argument("#")
+			s.pos++
+			start = s.pos
+		default:
+			s.pos++
+		}
+	}
+	flush()
+}
+
+// quoted skips an apostrophe and the text that it quotes.
+func (s *icuScanner) quoted() {
+	s.pos++
+	if s.pos >= len(s.src) || !strings.ContainsRune("{}#|", rune(s.src[s.pos])) {
+		if s.pos < len(s.src) && s.src[s.pos] == '\'' {
+			s.pos++
+		}
+		return
+	}
+	if i := strings.IndexByte(s.src[s.pos:], '\''); i >= 0 {
+		s.pos += i + 1
+	} else {
+		s.pos = len(s.src)
+	}
+}
+
+// arg scans the argument that starts at s.pos.
+func (s *icuScanner) arg() {
+	start := s.pos
+	end := strings.IndexAny(s.src[start:], ",}")
+	if end < 0 || s.src[start+end] == '}' {
+		s.pos = len(s.src)
+		if end >= 0 {
+			s.pos = start + end + 1
+		}
+		s.argument(s.src[start:s.pos])
+		return
+	}
+	typeStart := start + end + 1
+	typeEnd := strings.IndexAny(s.src[typeStart:], ",}")
+	if typeEnd < 0 {
+		s.pos = len(s.src)
+		s.argument(s.src[start:])
+		return
+	}
+	switch strings.TrimSpace(s.src[typeStart : typeStart+typeEnd]) {
+	case "plural", "selectordinal", "select":
+	default:
+		// Arguments like {Count, number} have no messages.
+		s.pos = typeStart
+		for depth := 1; s.pos < len(s.src) && depth > 0; s.pos++ {
+			switch s.src[s.pos] {
+			case '{':
+				depth++
+			case '}':
+				depth--
+			}
+		}
+		s.argument(s.src[start:s.pos])
+		return
+	}
+	// Variants are selectors followed by messages in braces, up to the '}' that ends the argument.
+	s.pos = typeStart + typeEnd
+	for s.pos < len(s.src) {
+		i := strings.IndexAny(s.src[s.pos:], "{}")
+		if i < 0 {
+			s.argument(s.src[start:])
+			s.pos = len(s.src)
+			return
+		}
+		s.pos += i + 1
+		s.argument(s.src[start:s.pos])
+		if s.src[s.pos-1] == '}' {
+			return
+		}
+		s.message(true)
+		start = s.pos
+		if s.pos < len(s.src) {
+			// The '}' that ends the variant.
+			s.pos++
+		}
+	}
+	s.argument(s.src[start:])
+}
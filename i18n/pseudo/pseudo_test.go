@@ -0,0 +1,107 @@
+package pseudo
+
+import (
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/i18n/language"
+	"github.com/nicksnyder/go-i18n/i18n/translation"
+)
+
+func TestTemplate(t *testing.T) {
+	tests := []struct {
+		method   Method
+		src      string
+		syntax   string
+		expected string
+	}{
+		{Accented, "", translation.GoTemplateSyntax, ""},
+		{Accented, "Hello {{.Person}}", translation.GoTemplateSyntax, "[Ĥéļļö {{.Person}} one]"},
+		{Accented, `{{printf "}}"}} x {{if .A}}yes{{else}}no{{end}}`, translation.GoTemplateSyntax, `[{{printf "}}"}} ẋ {{if .A}}ýéš{{else}}ñö{{end}} one]`},
+		{Accented, "{{.Count}} files in {{.Folder}}", translation.GoTemplateSyntax, "[{{.Count}} ƒîļéš îñ {{.Folder}} one]"},
+		{
+			Accented,
+			"{Person} has {Count, plural, offset:1 =0 {no files} one {# file} other {# files in {Folder}}}",
+			translation.ICUSyntax,
+			"[{Person} ĥåš {Count, plural, offset:1 =0 {ñö ƒîļéš} one {# ƒîļé} other {# ƒîļéš îñ {Folder}}} one two three]",
+		},
+		{
+			Accented,
+			"It''s '{'quoted'}' {N, number} {G, select, female {her} other {their}}",
+			translation.ICUSyntax,
+			"[Îţ''š '{'ǫûöţéð'}' {N, number} {G, select, female {ĥéŕ} other {ţĥéîŕ}} one two three]",
+		},
+		{Bidi, "Hello {{.Person}}", translation.GoTemplateSyntax, "\u200f\u202eHello\u202c\u200f {{.Person}}"},
+		{
+			Bidi,
+			"{Count, plural, one {# file} other {# files}}",
+			translation.ICUSyntax,
+			"{Count, plural, one {# \u200f\u202efile\u202c\u200f} other {# \u200f\u202efiles\u202c\u200f}}",
+		},
+	}
+	for _, test := range tests {
+		if actual := test.method.Template(test.src, test.syntax); actual != test.expected {
+			t.Errorf("%s.Template(%q, %q) = %q; expected %q", test.method.Tag(), test.src, test.syntax, actual, test.expected)
+		}
+	}
+}
+
+func TestHTMLTemplate(t *testing.T) {
+	tests := []struct {
+		method   Method
+		src      string
+		syntax   string
+		expected string
+	}{
+		{Accented, "<b>Bold</b> &amp; <a href=\"{{.URL}}\" title=\"Link\">link</a>", translation.GoTemplateSyntax, "[<b>ßöļð</b> &amp; <a href=\"{{.URL}}\" title=\"Link\">ļîñķ</a> one]"},
+		{Accented, "Tom & Jerry&#39;s", translation.GoTemplateSyntax, "[Ţöɱ & Ĵéŕŕý&#39;š one two]"},
+		{Accented, "{Count, plural, one {<i>#</i> file} other {<i>#</i> files}}", translation.ICUSyntax, "[{Count, plural, one {<i>#</i> ƒîļé} other {<i>#</i> ƒîļéš}} one two]"},
+		{Bidi, "<b>Hello</b> world", translation.GoTemplateSyntax, "<b>\u200f\u202eHello\u202c\u200f</b> \u200f\u202eworld\u202c\u200f"},
+	}
+	for _, test := range tests {
+		if actual := test.method.HTMLTemplate(test.src, test.syntax); actual != test.expected {
+			t.Errorf("%s.HTMLTemplate(%q, %q) = %q; expected %q", test.method.Tag(), test.src, test.syntax, actual, test.expected)
+		}
+	}
+}
+
+func TestTranslation(t *testing.T) {
+	src, err := translation.NewTranslation(map[string]interface{}{
+		"id":          "files",
+		"translation": map[string]interface{}{"one": "{{.Count}} file", "other": "{{.Count}} files"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pt, err := Translation(src, Accented)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual := pt.Template(language.Other).Execute(map[string]interface{}{"Count": 2}); actual != "[2 ƒîļéš one]" {
+		t.Errorf("got %q", actual)
+	}
+
+	src, err = translation.NewTranslation(map[string]interface{}{"id": "bold", "html": true, "translation": "<b>Bold</b>"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pt, err = Translation(src, Accented); err != nil {
+		t.Fatal(err)
+	}
+	if actual := pt.Template(language.Other).Execute(nil); actual != "[<b>ßöļð</b> one]" {
+		t.Errorf("got %q", actual)
+	}
+}
+
+func TestMethodOf(t *testing.T) {
+	for _, m := range []Method{Accented, Bidi} {
+		if actual, ok := MethodOf(m.Tag()); !ok || actual != m {
+			t.Errorf("MethodOf(%q) = %v, %t; expected %v, true", m.Tag(), actual, ok, m)
+		}
+		if plurals := language.MustParse(m.Tag())[0].SortedPlurals(); len(plurals) != 2 {
+			t.Errorf("%s has plural categories %v; expected the plural categories of English", m.Tag(), plurals)
+		}
+	}
+	if _, ok := MethodOf("en-US"); ok {
+		t.Error("en-US is not a pseudo-locale")
+	}
+}
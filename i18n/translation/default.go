@@ -0,0 +1,48 @@
+package translation
+
+import (
+	"github.com/nicksnyder/go-i18n/i18n/language"
+)
+
+// Default is the default message of a translation id, written in the source language.
+// A translate function uses it instead of the translation id when the bundle has no translation
+// for the translation id. It is passed as the first argument after the translation id:
+//
+//	T("person_greeting", translation.Default{Other: "Hello {{.Person}}"}, data)
+//	T("your_unread_email_count", translation.Default{One: "You have {{.Count}} unread email.", Other: "You have {{.Count}} unread emails."}, count)
+//
+// A Default with only an Other message is a non-plural translation.
+// goi18n extract copies default messages into the translation file of the source language.
+type Default struct {
+	Zero, One, Two, Few, Many, Other string
+}
+
+// Plural reports whether d has messages for plural categories other than Other.
+func (d Default) Plural() bool {
+	return d.Zero != "" || d.One != "" || d.Two != "" || d.Few != "" || d.Many != ""
+}
+
+// Translation returns the translation with id in context whose templates are the messages of d.
+func (d Default) Translation(context, id string) (Translation, error) {
+	data := map[string]interface{}{"id": id, "translation": d.Other}
+	if context != "" {
+		data["context"] = context
+	}
+	if d.Plural() {
+		templates := make(map[string]interface{})
+		for pc, message := range map[language.Plural]string{
+			language.Zero:  d.Zero,
+			language.One:   d.One,
+			language.Two:   d.Two,
+			language.Few:   d.Few,
+			language.Many:  d.Many,
+			language.Other: d.Other,
+		} {
+			if message != "" || pc == language.Other {
+				templates[string(pc)] = message
+			}
+		}
+		data["translation"] = templates
+	}
+	return NewTranslation(data)
+}
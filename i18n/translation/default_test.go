@@ -0,0 +1,28 @@
+package translation
+
+import (
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/i18n/language"
+)
+
+func TestDefaultTranslation(t *testing.T) {
+	single, err := Default{Other: "Open"}.Translation("verb", "open")
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifyDeepEqual(t, KeyOf(single), Key("verb", "open"))
+	verifyDeepEqual(t, Plurals(single), []language.Plural(nil))
+	verifyDeepEqual(t, single.Template(language.Other).String(), "Open")
+
+	plural, err := Default{One: "{{.Count}} file", Other: "{{.Count}} files"}.Translation("", "files")
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifyDeepEqual(t, Plurals(plural), []language.Plural{language.One, language.Other})
+	verifyDeepEqual(t, plural.Template(language.One).String(), "{{.Count}} file")
+
+	if _, err := (Default{Other: "{{.Count"}).Translation("", "files"); err == nil {
+		t.Error("expected an error for a default message that fails to parse")
+	}
+}
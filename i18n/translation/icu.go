@@ -0,0 +1,473 @@
+package translation
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/nicksnyder/go-i18n/i18n/language"
+)
+
+// icuMessage is a parsed ICU MessageFormat pattern.
+// http://icu-project.org/apiref/icu4j/com/ibm/icu/text/MessageFormat.html
+type icuMessage []icuNode
+
+type icuNode interface {
+	format(buf *bytes.Buffer, ctx *icuContext)
+}
+
+// icuContext is the state of the execution of an icuMessage.
+type icuContext struct {
+	lang *language.Language
+	args interface{}
+
+	// number replaces # in the variants of a plural or selectordinal argument.
+	number string
+
+	// html escapes the arguments for HTML, except template.HTML values.
+	html bool
+}
+
+func (m icuMessage) execute(lang *language.Language, args interface{}) string {
+	var buf bytes.Buffer
+	m.format(&buf, &icuContext{lang: lang, args: args})
+	return buf.String()
+}
+
+func (m icuMessage) format(buf *bytes.Buffer, ctx *icuContext) {
+	for _, node := range m {
+		node.format(buf, ctx)
+	}
+}
+
+type icuText string
+
+func (t icuText) format(buf *bytes.Buffer, ctx *icuContext) {
+	buf.WriteString(string(t))
+}
+
+// icuPound is a # in a variant of a plural or selectordinal argument.
+type icuPound struct{}
+
+func (icuPound) format(buf *bytes.Buffer, ctx *icuContext) {
+	if ctx.number == "" {
+		buf.WriteByte('#')
+		return
+	}
+	buf.WriteString(ctx.number)
+}
+
+// icuArgument is a {name} or {name, number} argument.
+type icuArgument struct {
+	name string
+	typ  string
+}
+
+func (a *icuArgument) format(buf *bytes.Buffer, ctx *icuContext) {
+	v, ok := icuArg(ctx.args, a.name)
+	if !ok {
+		fmt.Fprintf(buf, "{%s}", a.name)
+		return
+	}
+	if a.typ == "number" {
+		if n, ok := icuNumber(v); ok {
+			buf.WriteString(n)
+			return
+		}
+	}
+	if _, ok := v.(htmltemplate.HTML); ok || !ctx.html {
+		fmt.Fprint(buf, v)
+	} else {
+		buf.WriteString(htmltemplate.HTMLEscapeString(fmt.Sprint(v)))
+	}
+}
+
+type icuVariant struct {
+	selector string
+	message  icuMessage
+}
+
+// icuSelect is a {name, select, ...} argument.
+type icuSelect struct {
+	name     string
+	variants []icuVariant
+}
+
+func (s *icuSelect) format(buf *bytes.Buffer, ctx *icuContext) {
+	selector := language.Other
+	if v, ok := icuArg(ctx.args, s.name); ok {
+		selector = fmt.Sprint(v)
+	}
+	// # is only replaced in the variants of plural arguments.
+	nested := *ctx
+	nested.number = ""
+	icuVariantMessage(s.variants, selector).format(buf, &nested)
+}
+
+// icuPlural is a {name, plural, ...} or {name, selectordinal, ...} argument.
+type icuPlural struct {
+	name     string
+	ordinal  bool
+	offset   int64
+	variants []icuVariant
+}
+
+func (p *icuPlural) format(buf *bytes.Buffer, ctx *icuContext) {
+	v, _ := icuArg(ctx.args, p.name)
+	n, ok := icuNumber(v)
+	if !ok {
+		icuVariantMessage(p.variants, language.Other).format(buf, ctx)
+		return
+	}
+	// Exact matches are compared with the number before the offset is subtracted.
+	for _, variant := range p.variants {
+		if strings.HasPrefix(variant.selector, "=") && icuNumberEquals(n, variant.selector[1:]) {
+			nested := *ctx
+			nested.number = icuSubtract(n, p.offset)
+			variant.message.format(buf, &nested)
+			return
+		}
+	}
+	nested := *ctx
+	nested.number = icuSubtract(n, p.offset)
+	category := language.Plural(language.Other)
+	if p.ordinal && ctx.lang != nil {
+		if c, err := ctx.lang.Ordinal(nested.number); err == nil {
+			category = c
+		}
+	} else if ctx.lang != nil && ctx.lang.PluralSpec != nil {
+		if c, err := ctx.lang.Plural(nested.number); err == nil {
+			category = c
+		}
+	}
+	icuVariantMessage(p.variants, string(category)).format(buf, &nested)
+}
+
+// icuVariantMessage returns the message of the variant that matches selector,
+// or the message of the "other" variant if there is none.
+func icuVariantMessage(variants []icuVariant, selector string) icuMessage {
+	var other icuMessage
+	for _, variant := range variants {
+		switch variant.selector {
+		case selector:
+			return variant.message
+		case language.Other:
+			other = variant.message
+		}
+	}
+	return other
+}
+
+// icuArg returns the value of the argument name in args,
+// which is a map with string keys or a struct.
+func icuArg(args interface{}, name string) (interface{}, bool) {
+	v := reflect.ValueOf(args)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, false
+		}
+		if mv := v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key())); mv.IsValid() {
+			return mv.Interface(), true
+		}
+	case reflect.Struct:
+		if f := v.FieldByName(name); f.IsValid() && f.CanInterface() {
+			return f.Interface(), true
+		}
+	}
+	return nil, false
+}
+
+// icuNumber formats v as a decimal number.
+// Strings are returned as they are so that their visible fraction digits are kept.
+func icuNumber(v interface{}) (string, bool) {
+	switch v := v.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(v), true
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case string:
+		if _, err := strconv.ParseFloat(v, 64); err == nil {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+func icuNumberEquals(n, exact string) bool {
+	a, err := strconv.ParseFloat(n, 64)
+	if err != nil {
+		return false
+	}
+	b, err := strconv.ParseFloat(exact, 64)
+	return err == nil && a == b
+}
+
+// icuSubtract subtracts offset from the decimal number n
+// and keeps the number of visible fraction digits of n.
+func icuSubtract(n string, offset int64) string {
+	if offset == 0 {
+		return n
+	}
+	if i, err := strconv.ParseInt(n, 10, 64); err == nil {
+		return strconv.FormatInt(i-offset, 10)
+	}
+	f, _ := strconv.ParseFloat(n, 64)
+	digits := 0
+	if i := strings.Index(n, "."); i != -1 {
+		digits = len(n) - i - 1
+	}
+	return strconv.FormatFloat(f-float64(offset), 'f', digits, 64)
+}
+
+// parseICU parses an ICU MessageFormat pattern.
+//
+// It supports simple arguments ({name} and {name, number}),
+// plural, selectordinal and select arguments, which may be nested,
+// and apostrophe quoting.
+func parseICU(src string) (icuMessage, error) {
+	p := &icuParser{src: src}
+	msg, err := p.parseMessage(false)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.src) {
+		return nil, p.errorf("unmatched '}'")
+	}
+	return msg, nil
+}
+
+type icuParser struct {
+	src string
+	pos int
+}
+
+func (p *icuParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("invalid ICU message %q at offset %d: %s", p.src, p.pos, fmt.Sprintf(format, args...))
+}
+
+// parseMessage parses text and arguments up to the next unmatched '}' or the end of the pattern.
+// inPlural is true in the variants of plural and selectordinal arguments, where # is the number.
+func (p *icuParser) parseMessage(inPlural bool) (icuMessage, error) {
+	var msg icuMessage
+	var text bytes.Buffer
+	flush := func() {
+		if text.Len() > 0 {
+			msg = append(msg, icuText(text.String()))
+			text.Reset()
+		}
+	}
+	for p.pos < len(p.src) {
+		switch c := p.src[p.pos]; {
+		case c == '{':
+			flush()
+			node, err := p.parseArgument()
+			if err != nil {
+				return nil, err
+			}
+			msg = append(msg, node)
+		case c == '}':
+			flush()
+			return msg, nil
+		case c == '#' && inPlural:
+			flush()
+			msg = append(msg, icuPound{})
+			p.pos++
+		case c == '\'':
+			p.parseQuoted(&text, inPlural)
+		default:
+			text.WriteByte(c)
+			p.pos++
+		}
+	}
+	flush()
+	return msg, nil
+}
+
+// parseQuoted parses an apostrophe.
+// Two apostrophes are a literal apostrophe, an apostrophe before a syntax character starts quoted text
+// up to the next single apostrophe, and any other apostrophe is literal.
+func (p *icuParser) parseQuoted(text *bytes.Buffer, inPlural bool) {
+	p.pos++
+	if p.pos == len(p.src) {
+		text.WriteByte('\'')
+		return
+	}
+	switch c := p.src[p.pos]; {
+	case c == '\'':
+		text.WriteByte('\'')
+		p.pos++
+		return
+	case c == '{' || c == '}' || (c == '#' && inPlural):
+	default:
+		text.WriteByte('\'')
+		return
+	}
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		p.pos++
+		if c != '\'' {
+			text.WriteByte(c)
+			continue
+		}
+		if p.pos < len(p.src) && p.src[p.pos] == '\'' {
+			text.WriteByte('\'')
+			p.pos++
+			continue
+		}
+		return
+	}
+}
+
+func (p *icuParser) parseArgument() (icuNode, error) {
+	p.pos++
+	p.skipSpace()
+	name := p.parseWord()
+	if name == "" {
+		return nil, p.errorf("missing argument name")
+	}
+	p.skipSpace()
+	if p.consume('}') {
+		return &icuArgument{name: name}, nil
+	}
+	if !p.consume(',') {
+		return nil, p.errorf("expected ',' or '}' after argument %q", name)
+	}
+	p.skipSpace()
+	typ := p.parseWord()
+	p.skipSpace()
+	switch typ {
+	case "number":
+		if !p.consume('}') {
+			return nil, p.errorf("number styles are not supported")
+		}
+		return &icuArgument{name: name, typ: typ}, nil
+	case "plural", "selectordinal":
+		if !p.consume(',') {
+			return nil, p.errorf("expected ',' after %s", typ)
+		}
+		return p.parsePlural(name, typ == "selectordinal")
+	case "select":
+		if !p.consume(',') {
+			return nil, p.errorf("expected ',' after select")
+		}
+		variants, err := p.parseVariants(false, nil)
+		if err != nil {
+			return nil, err
+		}
+		return &icuSelect{name: name, variants: variants}, nil
+	case "":
+		return nil, p.errorf("missing type of argument %q", name)
+	default:
+		return nil, p.errorf("unsupported argument type %q", typ)
+	}
+}
+
+func (p *icuParser) parsePlural(name string, ordinal bool) (icuNode, error) {
+	plural := &icuPlural{name: name, ordinal: ordinal}
+	p.skipSpace()
+	if strings.HasPrefix(p.src[p.pos:], "offset:") {
+		p.pos += len("offset:")
+		p.skipSpace()
+		offset, err := strconv.ParseInt(p.parseWord(), 10, 64)
+		if err != nil || offset < 0 {
+			return nil, p.errorf("invalid plural offset")
+		}
+		plural.offset = offset
+	}
+	variants, err := p.parseVariants(true, func(selector string) error {
+		if strings.HasPrefix(selector, "=") {
+			if _, err := strconv.ParseFloat(selector[1:], 64); err != nil {
+				return fmt.Errorf("invalid exact match %q", selector)
+			}
+			return nil
+		}
+		_, err := language.NewPlural(selector)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	plural.variants = variants
+	return plural, nil
+}
+
+// parseVariants parses the selector {message} pairs of a plural, selectordinal or select argument
+// and the '}' that ends the argument.
+func (p *icuParser) parseVariants(inPlural bool, validate func(selector string) error) ([]icuVariant, error) {
+	var variants []icuVariant
+	hasOther := false
+	for {
+		p.skipSpace()
+		if p.pos == len(p.src) {
+			return nil, p.errorf("unterminated argument")
+		}
+		if p.consume('}') {
+			break
+		}
+		selector := p.parseWord()
+		if selector == "" {
+			return nil, p.errorf("expected a selector")
+		}
+		if validate != nil {
+			if err := validate(selector); err != nil {
+				return nil, p.errorf("%s", err)
+			}
+		}
+		p.skipSpace()
+		if !p.consume('{') {
+			return nil, p.errorf("expected '{' after selector %q", selector)
+		}
+		msg, err := p.parseMessage(inPlural)
+		if err != nil {
+			return nil, err
+		}
+		if !p.consume('}') {
+			return nil, p.errorf("unterminated variant %q", selector)
+		}
+		variants = append(variants, icuVariant{selector, msg})
+		hasOther = hasOther || selector == language.Other
+	}
+	if !hasOther {
+		return nil, p.errorf("missing %q variant", language.Other)
+	}
+	return variants, nil
+}
+
+// parseWord parses an argument name, type, selector or offset.
+func (p *icuParser) parseWord() string {
+	start := p.pos
+	for p.pos < len(p.src) && !isICUSpace(p.src[p.pos]) && !strings.ContainsRune("{},'#", rune(p.src[p.pos])) {
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
+
+func (p *icuParser) consume(c byte) bool {
+	if p.pos < len(p.src) && p.src[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *icuParser) skipSpace() {
+	for p.pos < len(p.src) && isICUSpace(p.src[p.pos]) {
+		p.pos++
+	}
+}
+
+func isICUSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
@@ -0,0 +1,128 @@
+package translation
+
+import (
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/i18n/language"
+)
+
+func TestICUExecute(t *testing.T) {
+	en := language.MustParse("en")[0]
+	ar := language.MustParse("ar")[0]
+	items := "{Count, plural, one {# item} other {# items}}"
+	tests := []struct {
+		src      string
+		lang     *language.Language
+		args     interface{}
+		expected string
+	}{
+		{"hello", en, nil, "hello"},
+		{"hello {Person}", en, map[string]interface{}{"Person": "Bob"}, "hello Bob"},
+		{"hello {Person}", en, struct{ Person string }{"Bob"}, "hello Bob"},
+		{"hello {Person}", en, &struct{ Person string }{"Bob"}, "hello Bob"},
+		{"hello {Person}", en, map[string]string{"Person": "Bob"}, "hello Bob"},
+		{"hello { Person }", en, map[string]interface{}{"Person": "Bob"}, "hello Bob"},
+		{"hello {Person}", en, nil, "hello {Person}"},
+		{"{N, number} items", en, map[string]interface{}{"N": 1.5}, "1.5 items"},
+		{items, en, map[string]interface{}{"Count": 1}, "1 item"},
+		{items, en, map[string]interface{}{"Count": 2}, "2 items"},
+		{items, en, map[string]interface{}{"Count": "1.0"}, "1.0 items"},
+		{items, en, map[string]interface{}{"Count": "abc"}, "# items"},
+		{items, nil, map[string]interface{}{"Count": 1}, "1 items"},
+		{"{Count, plural, =0 {no items} one {# item} other {# items}}", en, map[string]interface{}{"Count": 0}, "no items"},
+		{"{Count, plural, zero {zero #} one {one #} two {two #} few {few #} many {many #} other {other #}}", ar, map[string]interface{}{"Count": 3}, "few 3"},
+		{"{Count, plural, offset:1 =0 {nobody} =1 {{Person}} one {{Person} and # other} other {{Person} and # others}}", en, map[string]interface{}{"Count": 2, "Person": "Bob"}, "Bob and 1 other"},
+		{"{Count, plural, offset:1 =0 {nobody} =1 {{Person}} one {{Person} and # other} other {{Person} and # others}}", en, map[string]interface{}{"Count": 4, "Person": "Bob"}, "Bob and 3 others"},
+		{"{Count, plural, offset:1 =0 {nobody} =1 {{Person}} one {{Person} and # other} other {{Person} and # others}}", en, map[string]interface{}{"Count": 1, "Person": "Bob"}, "Bob"},
+		{"{Gender, select, female {she} male {he} other {they}}", en, map[string]interface{}{"Gender": "female"}, "she"},
+		{"{Gender, select, female {she} male {he} other {they}}", en, map[string]interface{}{"Gender": "unknown"}, "they"},
+		{"{Gender, select, female {she} male {he} other {they}}", en, nil, "they"},
+		{"{Gender, select, female {{Count, plural, one {her # cat} other {her # cats}}} other {{Count, plural, one {their # cat} other {their # cats}}}}", en, map[string]interface{}{"Gender": "female", "Count": 2}, "her 2 cats"},
+		{"{Count, plural, other {{Gender, select, other {#}}}}", en, map[string]interface{}{"Count": 2}, "#"},
+		{"{Count, selectordinal, =1 {#st} other {#th}}", en, map[string]interface{}{"Count": 1}, "1st"},
+		{"{Count, selectordinal, =1 {#st} other {#th}}", en, map[string]interface{}{"Count": 4}, "4th"},
+		{"{Count, selectordinal, one {#st} two {#nd} few {#rd} other {#th}}", en, map[string]interface{}{"Count": 22}, "22nd"},
+		{"{Count, selectordinal, one {#st} two {#nd} few {#rd} other {#th}}", en, map[string]interface{}{"Count": 13}, "13th"},
+		{"it''s", en, nil, "it's"},
+		{"it's", en, nil, "it's"},
+		{"'{Person}' is {Person}", en, map[string]interface{}{"Person": "Bob"}, "{Person} is Bob"},
+		{"'{it''s}'", en, nil, "{it's}"},
+		{"{Count, plural, other {'#' is #}}", en, map[string]interface{}{"Count": 2}, "# is 2"},
+		{"# is not special", en, nil, "# is not special"},
+	}
+	for _, test := range tests {
+		tmpl, err := newTemplateSyntax(test.src, ICUSyntax)
+		if err != nil {
+			t.Errorf("newTemplateSyntax(%q) returned error: %s", test.src, err)
+			continue
+		}
+		if actual := tmpl.ExecuteLanguage(test.lang, test.args); actual != test.expected {
+			t.Errorf("%q.ExecuteLanguage(%v, %#v) = %q; expected %q", test.src, test.lang, test.args, actual, test.expected)
+		}
+	}
+}
+
+func TestICUParseError(t *testing.T) {
+	tests := []string{
+		"{",
+		"}",
+		"{}",
+		"{Person",
+		"{Person, }",
+		"{Person, date}",
+		"{N, number, percent}",
+		"{Count, plural}",
+		"{Count, plural, one {# item}}",
+		"{Count, plural, one {# item} other {# items}",
+		"{Count, plural, one {# item} other {# items",
+		"{Count, plural, some {# item} other {# items}}",
+		"{Count, plural, =x {# item} other {# items}}",
+		"{Count, plural, offset:x other {# items}}",
+		"{Count, plural, other # items}",
+		"{Gender, select, female {she}}",
+		"{{.Person}}",
+	}
+	for _, src := range tests {
+		if _, err := newTemplateSyntax(src, ICUSyntax); err == nil {
+			t.Errorf("newTemplateSyntax(%q) returned no error", src)
+		}
+	}
+}
+
+func TestNewTranslationSyntax(t *testing.T) {
+	tr, err := NewTranslation(map[string]interface{}{
+		"id":          "items",
+		"translation": "{Count, plural, one {# item} other {# items}}",
+		"syntax":      "icu",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	en := language.MustParse("en")[0]
+	if actual := tr.Template(language.Other).ExecuteLanguage(en, map[string]interface{}{"Count": 1}); actual != "1 item" {
+		t.Errorf("expected %q; got %q", "1 item", actual)
+	}
+	data := tr.MarshalInterface().(map[string]interface{})
+	if data["syntax"] != ICUSyntax {
+		t.Errorf("MarshalInterface() syntax = %#v; expected %#v", data["syntax"], ICUSyntax)
+	}
+	untranslated := tr.UntranslatedCopy().MarshalFlatInterface().(map[string]interface{})
+	if untranslated["syntax"] != ICUSyntax {
+		t.Errorf("UntranslatedCopy().MarshalFlatInterface() syntax = %#v; expected %#v", untranslated["syntax"], ICUSyntax)
+	}
+
+	if _, err := NewTranslation(map[string]interface{}{
+		"id":          "items",
+		"translation": map[string]interface{}{"one": "{Count} item", "other": "{Count} items"},
+		"syntax":      "icu",
+	}); err == nil {
+		t.Errorf("expected an error for a plural ICU translation")
+	}
+	if _, err := NewTranslation(map[string]interface{}{
+		"id":          "hello",
+		"translation": "hello",
+		"syntax":      "mustache",
+	}); err == nil {
+		t.Errorf("expected an error for an unsupported syntax")
+	}
+}
@@ -0,0 +1,103 @@
+package translation
+
+import "fmt"
+
+// Metadata describes a translation to translators.
+// It doesn't change how the translation is executed, although HTML changes how it is escaped in HTML templates.
+type Metadata struct {
+	// Description explains the meaning of the translation (e.g. "Greets the signed in user").
+	Description string
+
+	// Context describes where the translation is used (e.g. "verb" or "button label").
+	// It is part of the key of the translation, like msgctxt in gettext,
+	// so translations with the same id and different contexts are translated separately.
+	Context string
+
+	// MaxLength is the maximum number of characters of the translated text,
+	// or 0 if the length is not limited.
+	MaxLength int
+
+	// HTML reports that the translation is HTML markup, which is written to HTML templates without escaping
+	// (see bundle.Bundle.HTMLFuncMap). The template data of the translation is still escaped.
+	HTML bool
+}
+
+// newMetadata reads the optional "description", "context", "maxLength" and "html" keys of data.
+func newMetadata(data map[string]interface{}) (Metadata, error) {
+	var m Metadata
+	var ok bool
+	if v, exists := data["description"]; exists {
+		if m.Description, ok = v.(string); !ok {
+			return m, fmt.Errorf(`unsupported type for "description" key %T`, v)
+		}
+	}
+	if v, exists := data["context"]; exists {
+		if m.Context, ok = v.(string); !ok {
+			return m, fmt.Errorf(`unsupported type for "context" key %T`, v)
+		}
+	}
+	if v, exists := data["maxLength"]; exists {
+		switch n := v.(type) {
+		case int:
+			m.MaxLength = n
+		case int64:
+			m.MaxLength = int(n)
+		case float64:
+			m.MaxLength = int(n)
+			if float64(m.MaxLength) != n {
+				return m, fmt.Errorf(`"maxLength" key must be an integer; got %v`, n)
+			}
+		default:
+			return m, fmt.Errorf(`unsupported type for "maxLength" key %T`, v)
+		}
+		if m.MaxLength < 0 {
+			return m, fmt.Errorf(`"maxLength" key must not be negative; got %d`, m.MaxLength)
+		}
+	}
+	if v, exists := data["html"]; exists {
+		if m.HTML, ok = v.(bool); !ok {
+			return m, fmt.Errorf(`unsupported type for "html" key %T`, v)
+		}
+	}
+	return m, nil
+}
+
+// marshal adds the non-empty fields of m to data.
+func (m Metadata) marshal(data map[string]interface{}) {
+	if m.Description != "" {
+		data["description"] = m.Description
+	}
+	if m.Context != "" {
+		data["context"] = m.Context
+	}
+	if m.MaxLength != 0 {
+		data["maxLength"] = m.MaxLength
+	}
+	if m.HTML {
+		data["html"] = true
+	}
+}
+
+// merge returns m with its fields overwritten by the non-empty fields of other.
+func (m Metadata) merge(other Metadata) Metadata {
+	if other.Description != "" {
+		m.Description = other.Description
+	}
+	if other.Context != "" {
+		m.Context = other.Context
+	}
+	if other.MaxLength != 0 {
+		m.MaxLength = other.MaxLength
+	}
+	if other.HTML {
+		m.HTML = true
+	}
+	return m
+}
+
+// marshalFlat is similar to marshal except it leaves out Context,
+// which is part of the key of a translation in the flat format (see Key).
+func (m Metadata) marshalFlat(data map[string]interface{}) {
+	m.Context = ""
+	m.marshal(data)
+}
@@ -0,0 +1,77 @@
+package translation
+
+import (
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/i18n/language"
+)
+
+func TestMetadata(t *testing.T) {
+	en := language.MustParse("en")[0]
+	translations := []map[string]interface{}{
+		{"id": "open", "translation": "Open"},
+		{"id": "files", "translation": map[string]interface{}{"one": "{{.Count}} file", "other": "{{.Count}} files"}},
+		{"id": "invited", "select": "Gender", "translation": map[string]interface{}{"other": "{{.Person}} invited you"}},
+	}
+	for _, data := range translations {
+		data["description"] = "describes " + data["id"].(string)
+		data["maxLength"] = float64(20)
+		src, err := NewTranslation(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := Metadata{Description: "describes " + src.ID(), MaxLength: 20}
+		verifyDeepEqual(t, src.Metadata(), expected)
+
+		marshaled := src.MarshalInterface().(map[string]interface{})
+		verifyDeepEqual(t, marshaled["description"], expected.Description)
+		verifyDeepEqual(t, marshaled["maxLength"], expected.MaxLength)
+		flat := src.MarshalFlatInterface().(map[string]interface{})
+		verifyDeepEqual(t, flat["description"], expected.Description)
+
+		untranslated := src.UntranslatedCopy()
+		verifyDeepEqual(t, untranslated.Metadata(), expected)
+
+		dst, err := NewTranslation(map[string]interface{}{"id": src.ID(), "translation": "", "maxLength": 30})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := src.(*singleTranslation); ok {
+			merged := untranslated.Merge(dst)
+			verifyDeepEqual(t, merged.Metadata(), Metadata{Description: expected.Description, MaxLength: 30})
+			dst = dst.Backfill(src)
+			verifyDeepEqual(t, dst.Metadata(), Metadata{Description: expected.Description, MaxLength: 30})
+		} else {
+			verifyDeepEqual(t, untranslated.Normalize(en).Backfill(src).Metadata(), expected)
+		}
+	}
+
+	// The key of a translation in the flat format holds its context.
+	for _, data := range translations {
+		data["context"] = "menu"
+		tr, err := NewTranslation(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		verifyDeepEqual(t, tr.MarshalInterface().(map[string]interface{})["context"], "menu")
+		if _, ok := tr.MarshalFlatInterface().(map[string]interface{})["context"]; ok {
+			t.Errorf("MarshalFlatInterface() of %s has a context", tr.ID())
+		}
+	}
+
+	html, err := NewTranslation(map[string]interface{}{"id": "bold", "translation": "<b>{{.Text}}</b>", "html": true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifyDeepEqual(t, html.Metadata(), Metadata{HTML: true})
+	verifyDeepEqual(t, html.MarshalFlatInterface().(map[string]interface{})["html"], true)
+	if _, err := NewTranslation(map[string]interface{}{"id": "bold", "translation": "<b>bold</b>", "html": "yes"}); err == nil {
+		t.Errorf("expected an error for html %#v", "yes")
+	}
+
+	for _, maxLength := range []interface{}{"10", 1.5, -1} {
+		if _, err := NewTranslation(map[string]interface{}{"id": "open", "translation": "Open", "maxLength": maxLength}); err == nil {
+			t.Errorf("expected an error for maxLength %#v", maxLength)
+		}
+	}
+}
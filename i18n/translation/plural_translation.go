@@ -7,17 +7,38 @@ import (
 type pluralTranslation struct {
 	id        string
 	templates map[language.Plural]*template
+
+	// ordinal is true if the templates are chosen by the ordinal plural rules of the language.
+	ordinal bool
+
+	metadata Metadata
 }
 
 func (pt *pluralTranslation) MarshalInterface() interface{} {
-	return map[string]interface{}{
+	data := map[string]interface{}{
 		"id":          pt.id,
 		"translation": pt.templates,
 	}
+	if pt.ordinal {
+		data["ordinal"] = true
+	}
+	pt.metadata.marshal(data)
+	return data
 }
 
 func (pt *pluralTranslation) MarshalFlatInterface() interface{} {
-	return pt.templates
+	if !pt.ordinal && pt.metadata == (Metadata{Context: pt.metadata.Context}) {
+		return pt.templates
+	}
+	data := make(map[string]interface{}, len(pt.templates)+1)
+	for pc, t := range pt.templates {
+		data[string(pc)] = t
+	}
+	if pt.ordinal {
+		data["ordinal"] = true
+	}
+	pt.metadata.marshalFlat(data)
+	return data
 }
 
 func (pt *pluralTranslation) ID() string {
@@ -29,18 +50,27 @@ func (pt *pluralTranslation) Template(pc language.Plural) *template {
 }
 
 func (pt *pluralTranslation) UntranslatedCopy() Translation {
-	return &pluralTranslation{pt.id, make(map[language.Plural]*template)}
+	return &pluralTranslation{pt.id, make(map[language.Plural]*template), pt.ordinal, pt.metadata}
+}
+
+// plurals returns the plural categories of l that the translation uses.
+func (pt *pluralTranslation) plurals(l *language.Language) map[language.Plural]struct{} {
+	if pt.ordinal {
+		return l.OrdinalSpec().Plurals
+	}
+	return l.Plurals
 }
 
 func (pt *pluralTranslation) Normalize(l *language.Language) Translation {
+	plurals := pt.plurals(l)
 	// Delete plural categories that don't belong to this language.
 	for pc := range pt.templates {
-		if _, ok := l.Plurals[pc]; !ok {
+		if _, ok := plurals[pc]; !ok {
 			delete(pt.templates, pc)
 		}
 	}
 	// Create map entries for missing valid categories.
-	for pc := range l.Plurals {
+	for pc := range plurals {
 		if _, ok := pt.templates[pc]; !ok {
 			pt.templates[pc] = mustNewTemplate("")
 		}
@@ -49,17 +79,21 @@ func (pt *pluralTranslation) Normalize(l *language.Language) Translation {
 }
 
 func (pt *pluralTranslation) Backfill(src Translation) Translation {
+	if src == nil {
+		return pt
+	}
 	for pc, t := range pt.templates {
-		if (t == nil || t.src == "") && src != nil {
+		if t == nil || t.src == "" {
 			pt.templates[pc] = src.Template(language.Other)
 		}
 	}
+	pt.metadata = src.Metadata().merge(pt.metadata)
 	return pt
 }
 
 func (pt *pluralTranslation) Merge(t Translation) Translation {
 	other, ok := t.(*pluralTranslation)
-	if !ok || pt.ID() != t.ID() {
+	if !ok || KeyOf(pt) != KeyOf(t) || pt.ordinal != other.ordinal {
 		return t
 	}
 	for pluralCategory, template := range other.templates {
@@ -67,11 +101,12 @@ func (pt *pluralTranslation) Merge(t Translation) Translation {
 			pt.templates[pluralCategory] = template
 		}
 	}
+	pt.metadata = pt.metadata.merge(other.metadata)
 	return pt
 }
 
 func (pt *pluralTranslation) Incomplete(l *language.Language) bool {
-	for pc := range l.Plurals {
+	for pc := range pt.plurals(l) {
 		if t := pt.templates[pc]; t == nil || t.src == "" {
 			return true
 		}
@@ -79,4 +114,28 @@ func (pt *pluralTranslation) Incomplete(l *language.Language) bool {
 	return false
 }
 
+func (pt *pluralTranslation) Ordinal() bool {
+	return pt.ordinal
+}
+
+func (pt *pluralTranslation) Metadata() Metadata {
+	return pt.metadata
+}
+
 var _ = Translation(&pluralTranslation{})
+
+// Plurals returns the plural categories that a plural translation has templates for,
+// in CLDR order, or nil if t is not a plural translation.
+func Plurals(t Translation) []language.Plural {
+	pt, ok := t.(*pluralTranslation)
+	if !ok {
+		return nil
+	}
+	plurals := make([]language.Plural, 0, len(pt.templates))
+	for _, pc := range []language.Plural{language.Zero, language.One, language.Two, language.Few, language.Many, language.Other} {
+		if _, ok := pt.templates[pc]; ok {
+			plurals = append(plurals, pc)
+		}
+	}
+	return plurals
+}
@@ -20,7 +20,7 @@ func pluralTranslationFixture(t *testing.T, id string, pluralCategories ...langu
 	for _, pc := range pluralCategories {
 		templates[pc] = mustTemplate(t, string(pc))
 	}
-	return &pluralTranslation{id, templates}
+	return &pluralTranslation{id: id, templates: templates}
 }
 
 func verifyDeepEqual(t *testing.T, actual, expected interface{}) {
@@ -48,6 +48,25 @@ func TestPluralTranslationMerge(t *testing.T) {
 	})
 }
 
+func TestPluralTranslationNormalizeOrdinal(t *testing.T) {
+	pt := pluralTranslationFixture(t, "id", language.One, language.Many, language.Other)
+	pt.ordinal = true
+	en := language.MustParse("en")[0]
+	pt.Normalize(en)
+	verifyDeepEqual(t, len(pt.templates), 4)
+	for _, pc := range []language.Plural{language.One, language.Two, language.Few, language.Other} {
+		if _, ok := pt.templates[pc]; !ok {
+			t.Errorf("Normalize() did not create ordinal category %s", pc)
+		}
+	}
+	if !pt.Incomplete(en) {
+		t.Errorf("Incomplete() returned false for missing ordinal categories")
+	}
+	if !pt.UntranslatedCopy().Ordinal() {
+		t.Errorf("UntranslatedCopy() is not ordinal")
+	}
+}
+
 /* Test implementations from old idea
 
 func TestCopy(t *testing.T) {
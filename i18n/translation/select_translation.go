@@ -0,0 +1,213 @@
+package translation
+
+import (
+	"fmt"
+
+	"github.com/nicksnyder/go-i18n/i18n/language"
+)
+
+// Selector is implemented by translations that have a variant for each value
+// of a key of the template data (e.g. a variant for each value of .Gender).
+type Selector interface {
+	Translation
+
+	// SelectKey returns the key of the template data that chooses the variant.
+	SelectKey() string
+
+	// Select returns the variant for the value of the select key in data,
+	// or the "other" variant if there is no variant for that value.
+	Select(data map[string]interface{}) Translation
+
+	// Variants returns the variants by value of the select key.
+	Variants() map[string]Translation
+}
+
+// OtherVariant is the variant of a select translation that is used
+// when there is no variant for the value of the select key.
+const OtherVariant = "other"
+
+type selectTranslation struct {
+	id  string
+	key string
+
+	// variants are single or plural translations with the same id.
+	variants map[string]Translation
+
+	metadata Metadata
+}
+
+func (st *selectTranslation) MarshalInterface() interface{} {
+	data := st.marshalVariants()
+	data["id"] = st.id
+	data["select"] = st.key
+	st.metadata.marshal(data)
+	return data
+}
+
+func (st *selectTranslation) MarshalFlatInterface() interface{} {
+	data := st.marshalVariants()
+	flat := data["translation"].(map[string]interface{})
+	flat["select"] = st.key
+	st.metadata.marshalFlat(flat)
+	for _, k := range []string{"syntax", "ordinal"} {
+		if v, ok := data[k]; ok {
+			flat[k] = v
+		}
+	}
+	return flat
+}
+
+// marshalVariants returns the translations of the variants under the "translation" key
+// and the keys that the variants share, such as "syntax" and "ordinal".
+func (st *selectTranslation) marshalVariants() map[string]interface{} {
+	translations := make(map[string]interface{}, len(st.variants))
+	data := map[string]interface{}{"translation": translations}
+	for value, variant := range st.variants {
+		variantData := variant.MarshalInterface().(map[string]interface{})
+		translations[value] = variantData["translation"]
+		for _, k := range []string{"syntax", "ordinal"} {
+			if v, ok := variantData[k]; ok {
+				data[k] = v
+			}
+		}
+	}
+	return data
+}
+
+func (st *selectTranslation) ID() string {
+	return st.id
+}
+
+func (st *selectTranslation) SelectKey() string {
+	return st.key
+}
+
+func (st *selectTranslation) Select(data map[string]interface{}) Translation {
+	if v, ok := data[st.key]; ok && v != nil {
+		if variant := st.variants[fmt.Sprint(v)]; variant != nil {
+			return variant
+		}
+	}
+	return st.variants[OtherVariant]
+}
+
+func (st *selectTranslation) Variants() map[string]Translation {
+	return st.variants
+}
+
+func (st *selectTranslation) Template(pc language.Plural) *template {
+	if other := st.variants[OtherVariant]; other != nil {
+		return other.Template(pc)
+	}
+	return nil
+}
+
+func (st *selectTranslation) UntranslatedCopy() Translation {
+	variants := make(map[string]Translation, len(st.variants))
+	for value, variant := range st.variants {
+		variants[value] = variant.UntranslatedCopy()
+	}
+	return &selectTranslation{st.id, st.key, variants, st.metadata}
+}
+
+func (st *selectTranslation) Normalize(l *language.Language) Translation {
+	for value, variant := range st.variants {
+		st.variants[value] = variant.Normalize(l)
+	}
+	return st
+}
+
+func (st *selectTranslation) Backfill(src Translation) Translation {
+	for value, variant := range st.variants {
+		if srcSelect, ok := src.(*selectTranslation); ok {
+			st.variants[value] = variant.Backfill(srcSelect.Select(map[string]interface{}{srcSelect.key: value}))
+		} else {
+			st.variants[value] = variant.Backfill(src)
+		}
+	}
+	if src != nil {
+		st.metadata = src.Metadata().merge(st.metadata)
+	}
+	return st
+}
+
+func (st *selectTranslation) Merge(t Translation) Translation {
+	other, ok := t.(*selectTranslation)
+	if !ok || KeyOf(st) != KeyOf(t) || st.key != other.key {
+		return t
+	}
+	for value, variant := range other.variants {
+		if current := st.variants[value]; current != nil {
+			st.variants[value] = current.Merge(variant)
+		} else {
+			st.variants[value] = variant
+		}
+	}
+	st.metadata = st.metadata.merge(other.metadata)
+	return st
+}
+
+func (st *selectTranslation) Incomplete(l *language.Language) bool {
+	for _, variant := range st.variants {
+		if variant.Incomplete(l) {
+			return true
+		}
+	}
+	return false
+}
+
+func (st *selectTranslation) Ordinal() bool {
+	for _, variant := range st.variants {
+		if variant.Ordinal() {
+			return true
+		}
+	}
+	return false
+}
+
+func (st *selectTranslation) Metadata() Metadata {
+	return st.metadata
+}
+
+var _ = Selector(&selectTranslation{})
+
+func newSelectTranslation(id string, key interface{}, metadata Metadata, data map[string]interface{}) (Translation, error) {
+	st := &selectTranslation{id: id, variants: make(map[string]Translation), metadata: metadata}
+	var ok bool
+	if st.key, ok = key.(string); !ok || st.key == "" {
+		return nil, fmt.Errorf(`unsupported value for "select" key %#v`, key)
+	}
+	var variants map[string]interface{}
+	switch translation := data["translation"].(type) {
+	case map[interface{}]interface{}:
+		// The YAML parser uses interface{} keys so we first convert them to string keys.
+		variants = make(map[string]interface{}, len(translation))
+		for k, v := range translation {
+			variants[fmt.Sprint(k)] = v
+		}
+	case map[string]interface{}:
+		variants = translation
+	case nil:
+		return nil, fmt.Errorf(`missing "translation" key`)
+	default:
+		return nil, fmt.Errorf(`unsupported type for "translation" key %T of select translation`, translation)
+	}
+	if _, ok := variants[OtherVariant]; !ok {
+		return nil, fmt.Errorf(`select translation is missing the %q variant`, OtherVariant)
+	}
+	for value, v := range variants {
+		variantData := map[string]interface{}{"id": id, "translation": v}
+		if syntax, ok := data["syntax"]; ok {
+			variantData["syntax"] = syntax
+		}
+		if _, single := v.(string); !single && data["ordinal"] == true {
+			variantData["ordinal"] = true
+		}
+		variant, err := NewTranslation(variantData)
+		if err != nil {
+			return nil, fmt.Errorf("variant %q: %s", value, err)
+		}
+		st.variants[value] = variant
+	}
+	return st, nil
+}
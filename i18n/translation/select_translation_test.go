@@ -0,0 +1,123 @@
+package translation
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/i18n/language"
+)
+
+func selectTranslationFixture(t *testing.T) Translation {
+	tr, err := NewTranslation(map[string]interface{}{
+		"id":     "photos",
+		"select": "Gender",
+		"translation": map[string]interface{}{
+			"female": map[string]interface{}{"one": "her photo", "other": "her photos"},
+			"male":   "his photos",
+			"other":  map[string]interface{}{"one": "their photo", "other": "their photos"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tr
+}
+
+func TestSelectTranslationSelect(t *testing.T) {
+	st := selectTranslationFixture(t).(Selector)
+	if key := st.SelectKey(); key != "Gender" {
+		t.Errorf("SelectKey() = %q; expected %q", key, "Gender")
+	}
+	tests := []struct {
+		data     map[string]interface{}
+		plural   language.Plural
+		expected string
+	}{
+		{map[string]interface{}{"Gender": "female"}, language.One, "her photo"},
+		{map[string]interface{}{"Gender": "male"}, language.One, "his photos"},
+		{map[string]interface{}{"Gender": "unknown"}, language.Other, "their photos"},
+		{nil, language.One, "their photo"},
+	}
+	for _, test := range tests {
+		if actual := st.Select(test.data).Template(test.plural).String(); actual != test.expected {
+			t.Errorf("Select(%v).Template(%s) = %q; expected %q", test.data, test.plural, actual, test.expected)
+		}
+	}
+}
+
+func TestSelectTranslationMarshal(t *testing.T) {
+	tr := selectTranslationFixture(t)
+	data := tr.MarshalInterface().(map[string]interface{})
+	verifyDeepEqual(t, data["select"], "Gender")
+	buf, err := json.Marshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(buf, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := NewTranslation(decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifyDeepEqual(t, parsed, tr)
+
+	flat := tr.MarshalFlatInterface().(map[string]interface{})
+	verifyDeepEqual(t, flat["select"], "Gender")
+	verifyDeepEqual(t, flat["male"], data["translation"].(map[string]interface{})["male"])
+}
+
+func TestSelectTranslationMergeAndBackfill(t *testing.T) {
+	en := language.MustParse("en")[0]
+	src := selectTranslationFixture(t)
+	dst := src.UntranslatedCopy().Normalize(en)
+	if !dst.Incomplete(en) {
+		t.Errorf("Incomplete() returned false for an untranslated copy")
+	}
+	dst = dst.Merge(selectTranslationFixture(t))
+	if dst.Incomplete(en) {
+		t.Errorf("Incomplete() returned true after merging all variants")
+	}
+
+	other, err := NewTranslation(map[string]interface{}{
+		"id":          "photos",
+		"select":      "Plural",
+		"translation": map[string]interface{}{"other": "photos"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if merged := dst.Merge(other); merged != other {
+		t.Errorf("Merge() of a translation with another select key did not replace the translation")
+	}
+
+	backfilled := src.UntranslatedCopy().Normalize(en).Backfill(src).(*selectTranslation)
+	if actual := backfilled.variants["male"].Template(language.Other).String(); actual != "his photos" {
+		t.Errorf("Backfill() set male variant to %q; expected %q", actual, "his photos")
+	}
+}
+
+func TestNewSelectTranslationOrdinal(t *testing.T) {
+	tr, err := NewTranslation(map[string]interface{}{
+		"id":      "place",
+		"select":  "Gender",
+		"ordinal": true,
+		"translation": map[string]interface{}{
+			"female": "she finished",
+			"other":  map[string]interface{}{"one": "{{.Count}}st", "other": "{{.Count}}th"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tr.Ordinal() {
+		t.Errorf("Ordinal() returned false")
+	}
+	if tr.(Selector).Select(map[string]interface{}{"Gender": "female"}).Ordinal() {
+		t.Errorf("Ordinal() returned true for a non-plural variant")
+	}
+	if _, err := NewTranslation(map[string]interface{}{"id": "place", "select": 1, "translation": map[string]interface{}{"other": "x"}}); err == nil {
+		t.Errorf("expected an error for a non-string select key")
+	}
+}
@@ -7,17 +7,35 @@ import (
 type singleTranslation struct {
 	id       string
 	template *template
+	metadata Metadata
 }
 
 func (st *singleTranslation) MarshalInterface() interface{} {
-	return map[string]interface{}{
+	data := map[string]interface{}{
 		"id":          st.id,
 		"translation": st.template,
 	}
+	if st.syntax() != GoTemplateSyntax {
+		data["syntax"] = st.syntax()
+	}
+	st.metadata.marshal(data)
+	return data
 }
 
 func (st *singleTranslation) MarshalFlatInterface() interface{} {
-	return map[string]interface{}{"other": st.template}
+	data := map[string]interface{}{"other": st.template}
+	if st.syntax() != GoTemplateSyntax {
+		data["syntax"] = st.syntax()
+	}
+	st.metadata.marshalFlat(data)
+	return data
+}
+
+func (st *singleTranslation) syntax() string {
+	if st.template == nil || st.template.syntax == "" {
+		return GoTemplateSyntax
+	}
+	return st.template.syntax
 }
 
 func (st *singleTranslation) ID() string {
@@ -29,7 +47,7 @@ func (st *singleTranslation) Template(pc language.Plural) *template {
 }
 
 func (st *singleTranslation) UntranslatedCopy() Translation {
-	return &singleTranslation{st.id, mustNewTemplate("")}
+	return &singleTranslation{st.id, &template{syntax: st.syntax()}, st.metadata}
 }
 
 func (st *singleTranslation) Normalize(language *language.Language) Translation {
@@ -37,20 +55,25 @@ func (st *singleTranslation) Normalize(language *language.Language) Translation
 }
 
 func (st *singleTranslation) Backfill(src Translation) Translation {
-	if (st.template == nil || st.template.src == "") && src != nil {
+	if src == nil {
+		return st
+	}
+	if st.template == nil || st.template.src == "" {
 		st.template = src.Template(language.Other)
 	}
+	st.metadata = src.Metadata().merge(st.metadata)
 	return st
 }
 
 func (st *singleTranslation) Merge(t Translation) Translation {
 	other, ok := t.(*singleTranslation)
-	if !ok || st.ID() != t.ID() {
+	if !ok || KeyOf(st) != KeyOf(t) {
 		return t
 	}
 	if other.template != nil && other.template.src != "" {
 		st.template = other.template
 	}
+	st.metadata = st.metadata.merge(other.metadata)
 	return st
 }
 
@@ -58,4 +81,12 @@ func (st *singleTranslation) Incomplete(l *language.Language) bool {
 	return st.template == nil || st.template.src == ""
 }
 
+func (st *singleTranslation) Ordinal() bool {
+	return false
+}
+
+func (st *singleTranslation) Metadata() Metadata {
+	return st.metadata
+}
+
 var _ = Translation(&singleTranslation{})
@@ -3,23 +3,55 @@ package translation
 import (
 	"bytes"
 	"encoding"
+	"fmt"
+	htmltemplate "html/template"
 	"strings"
+	"sync"
 	gotemplate "text/template"
+
+	"github.com/nicksnyder/go-i18n/i18n/language"
+)
+
+// Syntaxes of translation templates.
+// The syntax of a translation is chosen by its "syntax" key.
+const (
+	// GoTemplateSyntax is the default syntax, which is executed with text/template.
+	GoTemplateSyntax = "go"
+
+	// ICUSyntax is ICU MessageFormat (e.g. "{Count, plural, one {# item} other {# items}}").
+	ICUSyntax = "icu"
 )
 
 type template struct {
-	tmpl *gotemplate.Template
-	src  string
+	tmpl   *gotemplate.Template
+	icu    icuMessage
+	src    string
+	syntax string
+
+	// htmlTmpl is tmpl parsed with html/template, the first time it is rendered as HTML.
+	htmlOnce sync.Once
+	htmlTmpl *htmltemplate.Template
+	htmlErr  error
 }
 
 func newTemplate(src string) (*template, error) {
+	return newTemplateSyntax(src, GoTemplateSyntax)
+}
+
+func newTemplateSyntax(src, syntax string) (*template, error) {
+	switch syntax {
+	case "", GoTemplateSyntax:
+		syntax = GoTemplateSyntax
+	case ICUSyntax:
+	default:
+		return nil, fmt.Errorf("unsupported syntax %q", syntax)
+	}
+	tmpl := &template{syntax: syntax}
 	if src == "" {
-		return new(template), nil
+		return tmpl, nil
 	}
-
-	var tmpl template
 	err := tmpl.parseTemplate(src)
-	return &tmpl, err
+	return tmpl, err
 }
 
 func mustNewTemplate(src string) *template {
@@ -35,14 +67,58 @@ func (t *template) String() string {
 }
 
 func (t *template) Execute(args interface{}) string {
+	return t.ExecuteLanguage(nil, args)
+}
+
+// ExecuteLanguage executes the template with args.
+// ICU MessageFormat templates use the plural rules of lang to choose plural variants.
+// If executing the template fails, the error message is returned.
+func (t *template) ExecuteLanguage(lang *language.Language, args interface{}) string {
+	s, err := t.Render(lang, args)
+	if err != nil {
+		return err.Error()
+	}
+	return s
+}
+
+// Render is similar to ExecuteLanguage except it returns the error of executing the template.
+func (t *template) Render(lang *language.Language, args interface{}) (string, error) {
+	if t.icu != nil {
+		return t.icu.execute(lang, args), nil
+	}
 	if t.tmpl == nil {
-		return t.src
+		return t.src, nil
 	}
 	var buf bytes.Buffer
 	if err := t.tmpl.Execute(&buf, args); err != nil {
-		return err.Error()
+		return "", err
 	}
-	return buf.String()
+	return buf.String(), nil
+}
+
+// RenderHTML is similar to Render except it treats the template as trusted HTML and escapes the template data:
+// Go templates are executed with html/template, and the arguments of ICU MessageFormat templates
+// are escaped unless they are template.HTML values.
+func (t *template) RenderHTML(lang *language.Language, args interface{}) (string, error) {
+	if t.icu != nil {
+		var buf bytes.Buffer
+		t.icu.format(&buf, &icuContext{lang: lang, args: args, html: true})
+		return buf.String(), nil
+	}
+	if t.tmpl == nil {
+		return t.src, nil
+	}
+	t.htmlOnce.Do(func() {
+		t.htmlTmpl, t.htmlErr = htmltemplate.New(t.src).Parse(t.src)
+	})
+	if t.htmlErr != nil {
+		return "", t.htmlErr
+	}
+	var buf bytes.Buffer
+	if err := t.htmlTmpl.Execute(&buf, args); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
 }
 
 func (t *template) MarshalText() ([]byte, error) {
@@ -55,6 +131,10 @@ func (t *template) UnmarshalText(src []byte) error {
 
 func (t *template) parseTemplate(src string) (err error) {
 	t.src = src
+	if t.syntax == ICUSyntax {
+		t.icu, err = parseICU(src)
+		return
+	}
 	if strings.Contains(src, "{{") {
 		t.tmpl, err = gotemplate.New(src).Parse(src)
 	}
@@ -3,6 +3,7 @@ package translation
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/nicksnyder/go-i18n/i18n/language"
 )
@@ -12,6 +13,10 @@ type Translation interface {
 	// MarshalInterface returns the object that should be used
 	// to serialize the translation.
 	MarshalInterface() interface{}
+
+	// MarshalFlatInterface returns the object that should be used
+	// to serialize the translation in the flat format, under its key (see KeyOf),
+	// which holds its context.
 	MarshalFlatInterface() interface{}
 	ID() string
 	Template(language.Plural) *template
@@ -20,32 +25,107 @@ type Translation interface {
 	Backfill(src Translation) Translation
 	Merge(Translation) Translation
 	Incomplete(l *language.Language) bool
+
+	// Ordinal returns true if the plural form of the translation is chosen
+	// by the ordinal plural rules of the language (e.g. 1st, 2nd, 3rd, 4th).
+	Ordinal() bool
+
+	// Metadata returns the information about the translation for translators.
+	Metadata() Metadata
+}
+
+// ContextSeparator separates the context of a translation from its id in the key of the translation.
+// It is the same separator that gettext uses for msgctxt.
+const ContextSeparator = "\x04"
+
+// Key returns the key of the translation with id in context.
+// Translations with the same id but different contexts (e.g. "open" the verb and "open" the adjective)
+// have different keys. The key of a translation without a context is its id.
+func Key(context, id string) string {
+	if context == "" {
+		return id
+	}
+	return context + ContextSeparator + id
+}
+
+// SplitKey returns the context and the id of a key returned by Key.
+func SplitKey(key string) (context, id string) {
+	if i := strings.Index(key, ContextSeparator); i != -1 {
+		return key[:i], key[i+len(ContextSeparator):]
+	}
+	return "", key
+}
+
+// KeyOf returns the key of t, which is made of its id and the context of its Metadata.
+func KeyOf(t Translation) string {
+	return Key(t.Metadata().Context, t.ID())
 }
 
 // SortableByID implements sort.Interface for a slice of translations.
+// Translations with the same id are sorted by context.
 type SortableByID []Translation
 
-func (a SortableByID) Len() int           { return len(a) }
-func (a SortableByID) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
-func (a SortableByID) Less(i, j int) bool { return a[i].ID() < a[j].ID() }
+func (a SortableByID) Len() int      { return len(a) }
+func (a SortableByID) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a SortableByID) Less(i, j int) bool {
+	if a[i].ID() != a[j].ID() {
+		return a[i].ID() < a[j].ID()
+	}
+	return a[i].Metadata().Context < a[j].Metadata().Context
+}
 
 // NewTranslation reflects on data to create a new Translation.
 //
 // data["id"] must be a string and data["translation"] must be either a string
 // for a non-plural translation or a map[string]interface{} for a plural translation.
+//
+// data["syntax"] optionally chooses the syntax of the translation, GoTemplateSyntax or ICUSyntax.
+// ICU MessageFormat translations express plurals with plural arguments, so they must be non-plural.
+//
+// data["ordinal"] optionally declares that the plural categories of a plural translation
+// are ordinal categories (e.g. "one" for 1st and "two" for 2nd in English).
+//
+// data["select"] optionally names a key of the template data (e.g. "Gender") that chooses
+// between variants of the translation. data["translation"] is then a map from the values
+// of that key to a string or plural translation, and it must have an "other" variant.
+//
+// data["description"], data["context"], data["maxLength"] and data["html"] are the optional Metadata of the translation.
+// Translations with the same id and different contexts are different translations; see Key.
 func NewTranslation(data map[string]interface{}) (Translation, error) {
 	id, ok := data["id"].(string)
 	if !ok {
 		return nil, fmt.Errorf(`missing "id" key`)
 	}
+	syntax := GoTemplateSyntax
+	if s, ok := data["syntax"]; ok {
+		if syntax, ok = s.(string); !ok {
+			return nil, fmt.Errorf(`unsupported type for "syntax" key %T`, s)
+		}
+	}
+	ordinal := false
+	if o, ok := data["ordinal"]; ok {
+		if ordinal, ok = o.(bool); !ok {
+			return nil, fmt.Errorf(`unsupported type for "ordinal" key %T`, o)
+		}
+	}
+	metadata, err := newMetadata(data)
+	if err != nil {
+		return nil, err
+	}
+	if key, ok := data["select"]; ok {
+		return newSelectTranslation(id, key, metadata, data)
+	}
 	var pluralObject map[string]interface{}
 	switch translation := data["translation"].(type) {
 	case string:
-		tmpl, err := newTemplate(translation)
+		if ordinal {
+			return nil, fmt.Errorf(`ordinal translations must be plural`)
+		}
+		tmpl, err := newTemplateSyntax(translation, syntax)
 		if err != nil {
 			return nil, err
 		}
-		return &singleTranslation{id, tmpl}, nil
+		return &singleTranslation{id, tmpl, metadata}, nil
 	case map[interface{}]interface{}:
 		// The YAML parser uses interface{} keys so we first convert them to string keys.
 		pluralObject = make(map[string]interface{})
@@ -63,6 +143,9 @@ func NewTranslation(data map[string]interface{}) (Translation, error) {
 	default:
 		return nil, fmt.Errorf(`unsupported type for "translation" key %T`, translation)
 	}
+	if syntax == ICUSyntax {
+		return nil, fmt.Errorf(`plural translations do not support syntax %q; use a plural argument instead`, syntax)
+	}
 
 	templates := make(map[language.Plural]*template, len(pluralObject))
 	for k, v := range pluralObject {
@@ -80,5 +163,69 @@ func NewTranslation(data map[string]interface{}) (Translation, error) {
 		}
 		templates[pc] = tmpl
 	}
-	return &pluralTranslation{id, templates}, nil
+	return &pluralTranslation{id, templates, ordinal, metadata}, nil
+}
+
+// MapTemplates returns a copy of t whose templates are the results of f applied to the sources
+// and syntaxes of the templates of t, such as a pseudo-localized copy of t.
+// Empty templates stay empty. It returns an error if a result of f fails to parse.
+func MapTemplates(t Translation, f func(src, syntax string) string) (Translation, error) {
+	mapTemplate := func(tmpl *template) (*template, error) {
+		if tmpl == nil || tmpl.src == "" {
+			return tmpl, nil
+		}
+		return newTemplateSyntax(f(tmpl.src, tmpl.syntax), tmpl.syntax)
+	}
+	switch t := t.(type) {
+	case *singleTranslation:
+		tmpl, err := mapTemplate(t.template)
+		if err != nil {
+			return nil, err
+		}
+		return &singleTranslation{t.id, tmpl, t.metadata}, nil
+	case *pluralTranslation:
+		templates := make(map[language.Plural]*template, len(t.templates))
+		for pc, tmpl := range t.templates {
+			mapped, err := mapTemplate(tmpl)
+			if err != nil {
+				return nil, err
+			}
+			templates[pc] = mapped
+		}
+		return &pluralTranslation{t.id, templates, t.ordinal, t.metadata}, nil
+	case *selectTranslation:
+		variants := make(map[string]Translation, len(t.variants))
+		for value, variant := range t.variants {
+			mapped, err := MapTemplates(variant, f)
+			if err != nil {
+				return nil, fmt.Errorf("variant %q: %s", value, err)
+			}
+			variants[value] = mapped
+		}
+		return &selectTranslation{t.id, t.key, variants, t.metadata}, nil
+	}
+	return nil, fmt.Errorf("unsupported translation type %T", t)
+}
+
+// Copy returns a copy of t that Merge, Backfill and Normalize can change without changing t.
+// The copy shares the templates of t, which never change.
+func Copy(t Translation) Translation {
+	switch t := t.(type) {
+	case *singleTranslation:
+		c := *t
+		return &c
+	case *pluralTranslation:
+		templates := make(map[language.Plural]*template, len(t.templates))
+		for pc, tmpl := range t.templates {
+			templates[pc] = tmpl
+		}
+		return &pluralTranslation{t.id, templates, t.ordinal, t.metadata}
+	case *selectTranslation:
+		variants := make(map[string]Translation, len(t.variants))
+		for value, variant := range t.variants {
+			variants[value] = Copy(variant)
+		}
+		return &selectTranslation{t.id, t.key, variants, t.metadata}
+	}
+	return t
 }
@@ -3,6 +3,8 @@ package translation
 import (
 	"sort"
 	"testing"
+
+	"github.com/nicksnyder/go-i18n/i18n/language"
 )
 
 // Check this here to avoid unnecessary import of sort package.
@@ -15,3 +17,119 @@ func TestNewSingleTranslation(t *testing.T) {
 func TestNewPluralTranslation(t *testing.T) {
 	t.Skipf("not implemented")
 }
+
+func TestKey(t *testing.T) {
+	tests := []struct {
+		context, id, key string
+	}{
+		{"", "open", "open"},
+		{"verb", "open", "verb\x04open"},
+		{"", "", ""},
+	}
+	for _, test := range tests {
+		if key := Key(test.context, test.id); key != test.key {
+			t.Errorf("Key(%q, %q) = %q; expected %q", test.context, test.id, key, test.key)
+		}
+		if context, id := SplitKey(test.key); context != test.context || id != test.id {
+			t.Errorf("SplitKey(%q) = %q, %q; expected %q, %q", test.key, context, id, test.context, test.id)
+		}
+	}
+}
+
+func TestContext(t *testing.T) {
+	verb, err := NewTranslation(map[string]interface{}{"id": "open", "context": "verb", "translation": "Öffnen"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	adjective, err := NewTranslation(map[string]interface{}{"id": "open", "context": "adjective", "translation": "Offen"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key := KeyOf(verb); key != "verb\x04open" {
+		t.Errorf("KeyOf() = %q; expected %q", key, "verb\x04open")
+	}
+	if merged := verb.Merge(adjective); merged != adjective {
+		t.Errorf("Merge() of a translation with another context did not replace the translation")
+	}
+	translations := []Translation{verb, adjective}
+	sort.Sort(SortableByID(translations))
+	if translations[0] != adjective {
+		t.Errorf("translations with the same id are not sorted by context")
+	}
+}
+
+func TestMapTemplates(t *testing.T) {
+	prefix := func(src, syntax string) string {
+		return syntax + ":" + src
+	}
+	translations := []map[string]interface{}{
+		{"id": "open", "context": "verb", "translation": "Open"},
+		{"id": "files", "translation": map[string]interface{}{"one": "{{.Count}} file", "other": ""}},
+		{"id": "invited", "select": "Gender", "translation": map[string]interface{}{"female": "{Person} invited you", "other": "They invited you"}, "syntax": ICUSyntax},
+	}
+	for _, data := range translations {
+		tr, err := NewTranslation(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		mapped, err := MapTemplates(tr, prefix)
+		if err != nil {
+			t.Fatal(err)
+		}
+		verifyDeepEqual(t, KeyOf(mapped), KeyOf(tr))
+		verifyDeepEqual(t, mapped.Metadata(), tr.Metadata())
+		switch tr.ID() {
+		case "open":
+			verifyDeepEqual(t, mapped.Template(language.Other).String(), "go:Open")
+			verifyDeepEqual(t, tr.Template(language.Other).String(), "Open")
+		case "files":
+			verifyDeepEqual(t, mapped.Template(language.One).String(), "go:{{.Count}} file")
+			verifyDeepEqual(t, mapped.Template(language.Other).String(), "")
+		case "invited":
+			female := mapped.(Selector).Select(map[string]interface{}{"Gender": "female"})
+			verifyDeepEqual(t, female.Template(language.Other).String(), "icu:{Person} invited you")
+			verifyDeepEqual(t, mapped.Template(language.Other).String(), "icu:They invited you")
+		}
+	}
+
+	tr, err := NewTranslation(map[string]interface{}{"id": "hello", "translation": "Hello"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := MapTemplates(tr, func(src, syntax string) string { return "{{" + src }); err == nil {
+		t.Error("expected an error for a template that fails to parse")
+	}
+}
+
+func TestCopy(t *testing.T) {
+	tests := []struct {
+		src, other map[string]interface{}
+	}{
+		{
+			map[string]interface{}{"id": "open", "translation": "Open"},
+			map[string]interface{}{"id": "open", "translation": "Changed"},
+		},
+		{
+			map[string]interface{}{"id": "files", "translation": map[string]interface{}{"one": "{{.Count}} file", "other": "{{.Count}} files"}},
+			map[string]interface{}{"id": "files", "translation": map[string]interface{}{"one": "Changed", "other": "Changed"}},
+		},
+		{
+			map[string]interface{}{"id": "invited", "select": "Gender", "translation": map[string]interface{}{"female": "She invited you", "other": "They invited you"}},
+			map[string]interface{}{"id": "invited", "select": "Gender", "translation": map[string]interface{}{"female": "Changed", "other": "Changed"}},
+		},
+	}
+	for _, test := range tests {
+		tr, err := NewTranslation(test.src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		other, err := NewTranslation(test.other)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := tr.MarshalInterface()
+		merged := Copy(tr).Merge(other)
+		verifyDeepEqual(t, tr.MarshalInterface(), expected)
+		verifyDeepEqual(t, merged.MarshalInterface(), other.MarshalInterface())
+	}
+}
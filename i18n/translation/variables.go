@@ -0,0 +1,126 @@
+package translation
+
+import (
+	"sort"
+	"text/template/parse"
+)
+
+// Variables returns the sorted names of the template data fields that the templates of t use
+// (e.g. "Person" for "Hello {{.Person}}" or "Hello {Person}").
+//
+// The variables of all plural categories and select variants of t are returned.
+func Variables(t Translation) []string {
+	vars := make(map[string]struct{})
+	addVariables(t, vars)
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func addVariables(t Translation, vars map[string]struct{}) {
+	switch t := t.(type) {
+	case *singleTranslation:
+		t.template.addVariables(vars)
+	case *pluralTranslation:
+		for _, tmpl := range t.templates {
+			tmpl.addVariables(vars)
+		}
+	case *selectTranslation:
+		vars[t.key] = struct{}{}
+		for _, variant := range t.variants {
+			addVariables(variant, vars)
+		}
+	}
+}
+
+func (t *template) addVariables(vars map[string]struct{}) {
+	switch {
+	case t == nil:
+	case t.icu != nil:
+		t.icu.addVariables(vars)
+	case t.tmpl != nil && t.tmpl.Tree != nil:
+		addNodeVariables(t.tmpl.Tree.Root, vars)
+	}
+}
+
+// addNodeVariables adds the top level fields of the template data that node uses, like Person in {{.Person.Name}}.
+//
+// The bodies of {{with}} and {{range}} rebind dot, so only the fields of $ are variables in them
+// (e.g. Person in {{with .User}}{{$.Person}}{{end}}, but not Name in {{with .User}}{{.Name}}{{end}}).
+func addNodeVariables(node parse.Node, vars map[string]struct{}) {
+	addScopeVariables(node, vars, true)
+}
+
+// addScopeVariables is like addNodeVariables, except dot is the template data only if isData is true.
+func addScopeVariables(node parse.Node, vars map[string]struct{}, isData bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n != nil {
+			for _, child := range n.Nodes {
+				addScopeVariables(child, vars, isData)
+			}
+		}
+	case *parse.ActionNode:
+		addScopeVariables(n.Pipe, vars, isData)
+	case *parse.PipeNode:
+		if n != nil {
+			for _, cmd := range n.Cmds {
+				addScopeVariables(cmd, vars, isData)
+			}
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			addScopeVariables(arg, vars, isData)
+		}
+	case *parse.FieldNode:
+		if isData {
+			vars[n.Ident[0]] = struct{}{}
+		}
+	case *parse.VariableNode:
+		if n.Ident[0] == "$" && len(n.Ident) > 1 {
+			vars[n.Ident[1]] = struct{}{}
+		}
+	case *parse.ChainNode:
+		addScopeVariables(n.Node, vars, isData)
+	case *parse.IfNode:
+		addScopeVariables(n.Pipe, vars, isData)
+		addScopeVariables(n.List, vars, isData)
+		addScopeVariables(n.ElseList, vars, isData)
+	case *parse.RangeNode:
+		addRebindingVariables(&n.BranchNode, vars, isData)
+	case *parse.WithNode:
+		addRebindingVariables(&n.BranchNode, vars, isData)
+	case *parse.TemplateNode:
+		addScopeVariables(n.Pipe, vars, isData)
+	}
+}
+
+// addRebindingVariables adds the variables of a {{with}} or {{range}} node, whose body rebinds dot.
+// Its {{else}} body keeps dot.
+func addRebindingVariables(n *parse.BranchNode, vars map[string]struct{}, isData bool) {
+	addScopeVariables(n.Pipe, vars, isData)
+	addScopeVariables(n.List, vars, false)
+	addScopeVariables(n.ElseList, vars, isData)
+}
+
+func (m icuMessage) addVariables(vars map[string]struct{}) {
+	for _, node := range m {
+		switch n := node.(type) {
+		case *icuArgument:
+			vars[n.name] = struct{}{}
+		case *icuSelect:
+			vars[n.name] = struct{}{}
+			for _, variant := range n.variants {
+				variant.message.addVariables(vars)
+			}
+		case *icuPlural:
+			vars[n.name] = struct{}{}
+			for _, variant := range n.variants {
+				variant.message.addVariables(vars)
+			}
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package translation
+
+import (
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/i18n/language"
+)
+
+func TestVariables(t *testing.T) {
+	tests := []struct {
+		data     map[string]interface{}
+		expected []string
+	}{
+		{
+			data:     map[string]interface{}{"id": "hello", "translation": "Hello"},
+			expected: []string{},
+		},
+		{
+			data:     map[string]interface{}{"id": "hello", "translation": "Hello {{.Person.Name}}, you have {{if .Count}}{{.Count}}{{else}}no{{end}} messages {{range .Items}}{{.Title}}{{end}}"},
+			expected: []string{"Count", "Items", "Person"},
+		},
+		{
+			data:     map[string]interface{}{"id": "hello", "translation": "{{with .User}}Hello {{.Name}} from {{$.Company}}{{else}}Hello {{.Guest}}{{end}}"},
+			expected: []string{"Company", "Guest", "User"},
+		},
+		{
+			data:     map[string]interface{}{"id": "hello", "translation": "{{range $i, $item := .Items}}{{$item.Title}} {{.Price}}{{end}}"},
+			expected: []string{"Items"},
+		},
+		{
+			data:     map[string]interface{}{"id": "files", "translation": map[string]interface{}{"one": "{{.Count}} file", "other": "{{.Count}} files in {{.Folder}}"}},
+			expected: []string{"Count", "Folder"},
+		},
+		{
+			data:     map[string]interface{}{"id": "hello", "syntax": ICUSyntax, "translation": "{Person} has {Count, plural, one {# file in {Folder}} other {# files}}"},
+			expected: []string{"Count", "Folder", "Person"},
+		},
+		{
+			data:     map[string]interface{}{"id": "invited", "select": "Gender", "translation": map[string]interface{}{"female": "{{.Person}} invited you to her party", "other": "{{.Person}} invited you to {{.Party}}"}},
+			expected: []string{"Gender", "Party", "Person"},
+		},
+	}
+	for _, test := range tests {
+		tr, err := NewTranslation(test.data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		verifyDeepEqual(t, Variables(tr), test.expected)
+	}
+}
+
+func TestPlurals(t *testing.T) {
+	tr, err := NewTranslation(map[string]interface{}{"id": "files", "translation": map[string]interface{}{"other": "{{.Count}} files", "one": "{{.Count}} file"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifyDeepEqual(t, Plurals(tr), []language.Plural{language.One, language.Other})
+
+	tr, err = NewTranslation(map[string]interface{}{"id": "file", "translation": "file"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifyDeepEqual(t, Plurals(tr), []language.Plural(nil))
+}